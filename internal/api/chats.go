@@ -1,7 +1,6 @@
 package api
 
 import (
-	"fmt"
 	"net/url"
 	"strconv"
 )
@@ -44,9 +43,5 @@ func (c *Client) SearchChats(opts *SearchChatsOptions) ([]Chat, bool, string, er
 		return nil, false, "", err
 	}
 
-	if resp.Code != 0 {
-		return nil, false, "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Items, resp.Data.HasMore, resp.Data.PageToken, nil
 }
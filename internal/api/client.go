@@ -3,9 +3,12 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/yjwong/lark-cli/internal/auth"
@@ -14,13 +17,15 @@ import (
 
 const (
 	defaultTimeout = 30 * time.Second
+
+	// Retry settings for rate-limited and transient server failures
+	maxRetries     = 3
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 8 * time.Second
 )
 
 func getBaseURL() string {
-	if config.GetRegion() == "feishu" {
-		return "https://open.feishu.cn/open-apis"
-	}
-	return "https://open.larksuite.com/open-apis"
+	return config.GetOpenBaseURL() + "/open-apis"
 }
 
 // Client is the Lark API client
@@ -31,50 +36,102 @@ type Client struct {
 // NewClient creates a new API client
 func NewClient() *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
+		httpClient: auth.NewHTTPClient(defaultTimeout),
 	}
 }
 
-// doRequest performs an authenticated HTTP request
-func (c *Client) doRequest(method, path string, body interface{}, result interface{}) error {
-	// Ensure we have a valid token
-	if err := auth.EnsureValidToken(); err != nil {
-		return err
-	}
+// requestBuilder creates a fresh request for each attempt, so that request
+// bodies can be replayed when a request is retried
+type requestBuilder func() (*http.Request, error)
+
+// send executes a request, retrying with exponential backoff and jitter while
+// Lark reports a retryable failure. Failures are returned as *APIError.
+//
+// By default the response body is read and returned. If stream is set, a 2xx
+// response is returned with its body unread and the caller must close it.
+func (c *Client) send(method, path string, build requestBuilder, stream bool) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := build()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
 
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, fmt.Errorf("request failed: %w", err)
+		}
+
+		if stream && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil, nil
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		apiErr := checkResponse(method, path, resp, respBody)
+		if apiErr == nil {
+			return resp, respBody, nil
+		}
+
+		if !apiErr.Retryable() || attempt >= maxRetries {
+			return nil, nil, apiErr
+		}
+		time.Sleep(retryDelay(attempt, resp))
 	}
+}
 
-	url := getBaseURL() + path
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// retryDelay returns how long to wait before the next attempt. Server hints
+// (Retry-After, x-ogw-ratelimit-reset) take precedence over the computed backoff.
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	for _, header := range []string{"Retry-After", "X-Ogw-Ratelimit-Reset"} {
+		if secs, err := strconv.Atoi(resp.Header.Get(header)); err == nil && secs > 0 {
+			delay := time.Duration(secs) * time.Second
+			if delay > maxBackoff {
+				delay = maxBackoff
+			}
+			return delay
+		}
 	}
 
-	// Set headers
-	token := auth.GetTokenStore().GetAccessToken()
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	backoff := initialBackoff << attempt
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	// Full jitter: pick uniformly in [backoff/2, backoff)
+	half := backoff / 2
+	return half + rand.N(half)
+}
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+// doJSON performs a JSON request with the given bearer token and decodes the result
+func (c *Client) doJSON(method, path, token string, body interface{}, result interface{}) error {
+	var jsonBody []byte
+	if body != nil {
+		var err error
+		jsonBody, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
 	}
-	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	url := getBaseURL() + path
+	_, respBody, err := c.send(method, path, func() (*http.Request, error) {
+		var reqBody io.Reader
+		if jsonBody != nil {
+			reqBody = bytes.NewReader(jsonBody)
+		}
+		req, err := http.NewRequest(method, url, reqBody)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		return req, nil
+	}, false)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return err
 	}
 
 	// Parse response
@@ -87,6 +144,17 @@ func (c *Client) doRequest(method, path string, body interface{}, result interfa
 	return nil
 }
 
+// doRequest performs an authenticated HTTP request
+func (c *Client) doRequest(method, path string, body interface{}, result interface{}) error {
+	// Ensure we have a valid token
+	if err := auth.EnsureValidToken(); err != nil {
+		return err
+	}
+
+	token := auth.GetTokenStore().GetAccessToken()
+	return c.doJSON(method, path, token, body, result)
+}
+
 // Get performs a GET request
 func (c *Client) Get(path string, result interface{}) error {
 	return c.doRequest("GET", path, nil, result)
@@ -119,47 +187,8 @@ func (c *Client) doRequestWithTenantToken(method, path string, body interface{},
 		return err
 	}
 
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		reqBody = bytes.NewBuffer(jsonBody)
-	}
-
-	url := getBaseURL() + path
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers with tenant token
 	token := auth.GetTenantTokenStore().GetAccessToken()
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Parse response
-	if result != nil {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to parse response: %w", err)
-		}
-	}
-
-	return nil
+	return c.doJSON(method, path, token, body, result)
 }
 
 // PostWithTenantToken performs a POST request using tenant access token
@@ -177,6 +206,25 @@ func (c *Client) DeleteWithTenantToken(path string, result interface{}) error {
 	return c.doRequestWithTenantToken("DELETE", path, nil, result)
 }
 
+// download performs a GET request that returns binary data
+func (c *Client) download(path, token string) (io.ReadCloser, string, error) {
+	url := getBaseURL() + path
+	resp, _, err := c.send("GET", path, func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req, nil
+	}, true)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	return resp.Body, contentType, nil
+}
+
 // DownloadWithTenantToken performs a GET request that returns binary data
 // The caller is responsible for closing the returned ReadCloser
 func (c *Client) DownloadWithTenantToken(path string) (io.ReadCloser, string, error) {
@@ -185,31 +233,7 @@ func (c *Client) DownloadWithTenantToken(path string) (io.ReadCloser, string, er
 		return nil, "", err
 	}
 
-	url := getBaseURL() + path
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers with tenant token
-	token := auth.GetTenantTokenStore().GetAccessToken()
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, "", fmt.Errorf("request failed: %w", err)
-	}
-
-	// Check for error response (non-2xx status)
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		defer resp.Body.Close()
-		body, _ := io.ReadAll(resp.Body)
-		return nil, "", fmt.Errorf("API error (HTTP %d): %s", resp.StatusCode, string(body))
-	}
-
-	contentType := resp.Header.Get("Content-Type")
-	return resp.Body, contentType, nil
+	return c.download(path, auth.GetTenantTokenStore().GetAccessToken())
 }
 
 // Download performs a GET request that returns binary data using user access token
@@ -220,29 +244,14 @@ func (c *Client) Download(path string) (io.ReadCloser, string, error) {
 		return nil, "", err
 	}
 
-	url := getBaseURL() + path
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers with user token
-	token := auth.GetTokenStore().GetAccessToken()
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, "", fmt.Errorf("request failed: %w", err)
-	}
+	return c.download(path, auth.GetTokenStore().GetAccessToken())
+}
 
-	// Check for error response (non-2xx status)
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		defer resp.Body.Close()
-		body, _ := io.ReadAll(resp.Body)
-		return nil, "", fmt.Errorf("API error (HTTP %d): %s", resp.StatusCode, string(body))
+// AsAPIError extracts an *APIError from err, if there is one
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
 	}
-
-	contentType := resp.Header.Get("Content-Type")
-	return resp.Body, contentType, nil
+	return nil, false
 }
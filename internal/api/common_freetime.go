@@ -1,9 +1,6 @@
 package api
 
-import (
-	"fmt"
-	"time"
-)
+import "time"
 
 // CommonFreeTimeOptions configures a common free time query
 type CommonFreeTimeOptions struct {
@@ -40,9 +37,5 @@ func (c *Client) GetCommonFreeTime(opts CommonFreeTimeOptions) ([]FreeTimeSlot,
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Items, nil
 }
@@ -20,10 +20,6 @@ func (c *Client) GetUser(userID string, idType string) (*ContactUser, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.User, nil
 }
 
@@ -51,10 +47,6 @@ func (c *Client) ListUsersByDepartment(deptID string, pageSize int, pageToken st
 		return nil, false, "", err
 	}
 
-	if resp.Code != 0 {
-		return nil, false, "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Items, resp.Data.HasMore, resp.Data.PageToken, nil
 }
 
@@ -68,10 +60,6 @@ func (c *Client) GetDepartment(deptID string) (*Department, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Department, nil
 }
 
@@ -103,10 +91,6 @@ func (c *Client) SearchUsers(query string, pageSize int, pageToken string) ([]Se
 		return nil, false, "", err
 	}
 
-	if resp.Code != 0 {
-		return nil, false, "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Users, resp.Data.HasMore, resp.Data.PageToken, nil
 }
 
@@ -135,9 +119,5 @@ func (c *Client) SearchDepartments(query string, pageSize int, pageToken string)
 		return nil, false, "", err
 	}
 
-	if resp.Code != 0 {
-		return nil, false, "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Items, resp.Data.HasMore, resp.Data.PageToken, nil
 }
@@ -17,10 +17,6 @@ func (c *Client) GetDocument(documentID string) (*Document, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Document, nil
 }
 
@@ -35,10 +31,6 @@ func (c *Client) GetDocumentContent(documentID string) (string, error) {
 		return "", err
 	}
 
-	if resp.Code != 0 {
-		return "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Content, nil
 }
 
@@ -60,10 +52,6 @@ func (c *Client) GetDocumentBlocks(documentID string) ([]DocumentBlock, error) {
 			return nil, err
 		}
 
-		if resp.Code != 0 {
-			return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-		}
-
 		allBlocks = append(allBlocks, resp.Data.Items...)
 
 		if !resp.Data.HasMore || resp.Data.PageToken == "" {
@@ -89,10 +77,6 @@ func (c *Client) CreateDocument(title, folderToken string) (*Document, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Document, nil
 }
 
@@ -115,10 +99,6 @@ func (c *Client) CreateDocumentBlocks(documentID, blockID string, children []Doc
 		return nil, 0, err
 	}
 
-	if resp.Code != 0 {
-		return nil, 0, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Children, resp.Data.DocumentRevisionID, nil
 }
 
@@ -135,10 +115,6 @@ func (c *Client) UpdateDocumentBlock(documentID, blockID string, req UpdateBlock
 		return nil, 0, err
 	}
 
-	if resp.Code != 0 {
-		return nil, 0, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Block, resp.Data.DocumentRevisionID, nil
 }
 
@@ -167,10 +143,6 @@ func (c *Client) ListFolderItems(folderToken string, pageSize int, pageToken str
 	if err := c.Get(path, &resp); err != nil {
 		return nil, false, "", err
 	}
-	if resp.Code != 0 {
-		return nil, false, "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Files, resp.Data.HasMore, resp.Data.NextPageToken, nil
 }
 
@@ -193,10 +165,6 @@ func (c *Client) GetDocumentComments(fileToken, fileType string) ([]DocumentComm
 			return nil, err
 		}
 
-		if resp.Code != 0 {
-			return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-		}
-
 		allComments = append(allComments, resp.Data.Items...)
 
 		if !resp.Data.HasMore || resp.Data.PageToken == "" {
@@ -227,10 +195,6 @@ func (c *Client) GetMediaTempDownloadURL(fileToken, documentID string) (string,
 		return "", err
 	}
 
-	if resp.Code != 0 {
-		return "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	if len(resp.Data.TmpDownloadURLs) == 0 {
 		return "", fmt.Errorf("no download URL returned for token %s", fileToken)
 	}
@@ -301,10 +265,6 @@ func (c *Client) SearchDocuments(query string, ownerIDs, chatIDs, docTypes []str
 			return nil, 0, err
 		}
 
-		if resp.Code != 0 {
-			return nil, 0, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-		}
-
 		allResults = append(allResults, resp.Data.DocsEntities...)
 
 		// Check if we should continue (has_more and offset+count < 200)
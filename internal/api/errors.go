@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Lark error codes that indicate the request was rate limited and can be retried
+var rateLimitCodes = map[int]bool{
+	99991400: true, // App-level request frequency limit
+	11232:    true, // IM message send frequency limit
+	230020:   true, // IM operation frequency limit
+}
+
+// APIError is returned by every Client method when Lark rejects a request,
+// either with a non-2xx HTTP status or a non-zero `code` in the response body.
+type APIError struct {
+	HTTPStatus int    `json:"http_status"`
+	Code       int    `json:"lark_code,omitempty"`
+	Msg        string `json:"msg,omitempty"`
+	LogID      string `json:"log_id,omitempty"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("API error")
+	if e.Code != 0 {
+		fmt.Fprintf(&b, " (code %d", e.Code)
+	} else {
+		fmt.Fprintf(&b, " (HTTP %d", e.HTTPStatus)
+	}
+	if e.LogID != "" {
+		fmt.Fprintf(&b, ", log_id %s", e.LogID)
+	}
+	b.WriteString(")")
+	if e.Msg != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Msg)
+	}
+	return b.String()
+}
+
+// Details returns the structured error fields for JSON error output
+func (e *APIError) Details() interface{} {
+	return e
+}
+
+// Retryable reports whether the request may succeed if sent again
+func (e *APIError) Retryable() bool {
+	if e.HTTPStatus == http.StatusTooManyRequests || e.HTTPStatus >= 500 {
+		return true
+	}
+	return rateLimitCodes[e.Code]
+}
+
+// IsRateLimited reports whether Lark throttled the request
+func (e *APIError) IsRateLimited() bool {
+	return e.HTTPStatus == http.StatusTooManyRequests || rateLimitCodes[e.Code]
+}
+
+// errorEnvelope is the subset of a Lark response used to detect failures
+type errorEnvelope struct {
+	Code  int    `json:"code"`
+	Msg   string `json:"msg"`
+	Error *struct {
+		LogID string `json:"log_id"`
+	} `json:"error,omitempty"`
+}
+
+// maxErrorBodyLength limits how much of a non-JSON error body is kept in Msg
+const maxErrorBodyLength = 512
+
+// checkResponse inspects a response and returns an *APIError if it failed.
+// It returns nil for a 2xx response whose body carries `code` 0 (or no code).
+func checkResponse(method, path string, resp *http.Response, body []byte) *APIError {
+	var env errorEnvelope
+	parsed := json.Unmarshal(body, &env) == nil
+
+	ok := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if ok && (!parsed || env.Code == 0) {
+		return nil
+	}
+
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	apiErr := &APIError{
+		HTTPStatus: resp.StatusCode,
+		Method:     method,
+		Path:       path,
+		LogID:      resp.Header.Get("X-Tt-Logid"),
+	}
+
+	if parsed {
+		apiErr.Code = env.Code
+		apiErr.Msg = env.Msg
+		if env.Error != nil && env.Error.LogID != "" {
+			apiErr.LogID = env.Error.LogID
+		}
+	} else {
+		msg := strings.TrimSpace(string(body))
+		if len(msg) > maxErrorBodyLength {
+			msg = msg[:maxErrorBodyLength] + "..."
+		}
+		apiErr.Msg = msg
+	}
+
+	if apiErr.Msg == "" {
+		apiErr.Msg = http.StatusText(resp.StatusCode)
+	}
+
+	return apiErr
+}
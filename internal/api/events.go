@@ -103,10 +103,6 @@ func (c *Client) getInstanceView(calendarID string, startTime, endTime time.Time
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Items, nil
 }
 
@@ -131,10 +127,6 @@ func (c *Client) ListEventAttendees(calendarID, eventID string) ([]Attendee, err
 			return nil, err
 		}
 
-		if resp.Code != 0 {
-			return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-		}
-
 		allAttendees = append(allAttendees, resp.Data.Items...)
 
 		if !resp.Data.HasMore {
@@ -160,10 +152,6 @@ func (c *Client) CreateEventAttendees(calendarID, eventID string, attendees []At
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Attendees, nil
 }
 
@@ -176,10 +164,6 @@ func (c *Client) GetEvent(calendarID, eventID string) (*Event, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Event, nil
 }
 
@@ -208,10 +192,6 @@ func (c *Client) CreateEvent(calendarID string, req *CreateEventRequest) (*Event
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Event, nil
 }
 
@@ -238,10 +218,6 @@ func (c *Client) UpdateEvent(calendarID, eventID string, req *UpdateEventRequest
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Event, nil
 }
 
@@ -254,10 +230,6 @@ func (c *Client) DeleteEvent(calendarID, eventID string) error {
 		return err
 	}
 
-	if resp.Code != 0 {
-		return fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return nil
 }
 
@@ -284,10 +256,6 @@ func (c *Client) SearchEvents(calendarID, query string, startTime, endTime time.
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Items, nil
 }
 
@@ -397,10 +365,6 @@ func (c *Client) ReplyToEvent(calendarID, eventID, rsvpStatus string) error {
 		return err
 	}
 
-	if resp.Code != 0 {
-		return fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return nil
 }
 
@@ -418,10 +382,6 @@ func (c *Client) DeleteEventAttendees(calendarID, eventID string, attendeeIDs []
 		return err
 	}
 
-	if resp.Code != 0 {
-		return fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return nil
 }
 
@@ -469,10 +429,6 @@ func (c *Client) ListChatMemberAttendees(calendarID, eventID, attendeeID string)
 			return nil, err
 		}
 
-		if resp.Code != 0 {
-			return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-		}
-
 		allMembers = append(allMembers, resp.Data.Items...)
 
 		if !resp.Data.HasMore {
@@ -1,9 +1,6 @@
 package api
 
-import (
-	"fmt"
-	"time"
-)
+import "time"
 
 // FreebusyOptions configures a freebusy query
 type FreebusyOptions struct {
@@ -28,9 +25,5 @@ func (c *Client) GetFreebusy(opts FreebusyOptions) ([]FreebusyPeriod, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.FreebusyList, nil
 }
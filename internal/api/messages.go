@@ -75,10 +75,6 @@ func (c *Client) ListMessages(containerIDType, containerID string, opts *ListMes
 		return nil, false, "", err
 	}
 
-	if resp.Code != 0 {
-		return nil, false, "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Items, resp.Data.HasMore, resp.Data.PageToken, nil
 }
 
@@ -113,10 +109,6 @@ func (c *Client) ListMessageReactions(messageID string, opts *ListMessageReactio
 		return nil, false, "", err
 	}
 
-	if resp.Code != 0 {
-		return nil, false, "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Items, resp.Data.HasMore, resp.Data.PageToken, nil
 }
 
@@ -161,25 +153,22 @@ func (c *Client) UploadMessageImage(filePath string) (string, error) {
 		return "", fmt.Errorf("failed to finalize upload: %w", err)
 	}
 
-	url := getBaseURL() + "/im/v1/images"
-	req, err := http.NewRequest("POST", url, &buf)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
+	path := "/im/v1/images"
+	url := getBaseURL() + path
 	token := auth.GetTenantTokenStore().GetAccessToken()
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
+	payload := buf.Bytes()
 
-	respBody, err := io.ReadAll(resp.Body)
+	_, respBody, err := c.send("POST", path, func() (*http.Request, error) {
+		req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req, nil
+	}, false)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", err
 	}
 
 	var uploadResp UploadImageResponse
@@ -187,10 +176,6 @@ func (c *Client) UploadMessageImage(filePath string) (string, error) {
 		return "", fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if uploadResp.Code != 0 {
-		return "", fmt.Errorf("API error %d: %s", uploadResp.Code, uploadResp.Msg)
-	}
-
 	if uploadResp.Data.ImageKey == "" {
 		return "", fmt.Errorf("API error: missing image_key")
 	}
@@ -217,10 +202,6 @@ func (c *Client) SendMessage(receiveIDType, receiveID, msgType, content string)
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return &resp, nil
 }
 
@@ -244,10 +225,6 @@ func (c *Client) ReplyMessage(messageID, msgType, content, rootID string, replyI
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return &resp, nil
 }
 
@@ -261,10 +238,6 @@ func (c *Client) RecallMessage(messageID string) error {
 		return err
 	}
 
-	if resp.Code != 0 {
-		return fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return nil
 }
 
@@ -277,10 +250,6 @@ func (c *Client) DeleteMessageReaction(messageID, reactionID string) (*MessageRe
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data, nil
 }
 
@@ -300,9 +269,5 @@ func (c *Client) AddMessageReaction(messageID, emojiType string) (*MessageReacti
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data, nil
 }
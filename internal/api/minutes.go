@@ -23,10 +23,6 @@ func (c *Client) GetMinute(minuteToken string) (*Minute, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Minute, nil
 }
 
@@ -74,9 +70,5 @@ func (c *Client) GetMinuteMediaURL(minuteToken string) (string, error) {
 		return "", err
 	}
 
-	if resp.Code != 0 {
-		return "", fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.DownloadURL, nil
 }
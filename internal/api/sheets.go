@@ -15,10 +15,6 @@ func (c *Client) GetSpreadsheetSheets(token string) ([]Sheet, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Sheets, nil
 }
 
@@ -34,10 +30,6 @@ func (c *Client) GetSheetMetadata(token, sheetID string) (*Sheet, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Sheet, nil
 }
 
@@ -53,10 +45,6 @@ func (c *Client) GetSheetData(token, rangeStr string) (*SheetValues, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data, nil
 }
 
@@ -79,10 +67,6 @@ func (c *Client) SetSheetData(token string, sheetRange string, values [][]any) (
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data, nil
 }
 
@@ -100,9 +84,5 @@ func (c *Client) CreateSpreadsheet(title, folderToken string) (*SpreadsheetInfo,
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error %d: %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.Spreadsheet, nil
 }
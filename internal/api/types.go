@@ -269,6 +269,40 @@ type OutputAuthStatus struct {
 	ScopeGroups   map[string]bool `json:"scope_groups,omitempty"`
 }
 
+// OutputProfileAuthStatus is the auth status of one profile for `auth status --all`
+type OutputProfileAuthStatus struct {
+	Profile    string `json:"profile"`
+	Active     bool   `json:"active"`
+	AppID      string `json:"app_id,omitempty"`
+	Region     string `json:"region"`
+	CanRefresh bool   `json:"can_refresh"`
+	OutputAuthStatus
+	Error string `json:"error,omitempty"`
+}
+
+// OutputProfileAuthStatusList is the `auth status --all` response for CLI
+type OutputProfileAuthStatusList struct {
+	Profiles []OutputProfileAuthStatus `json:"profiles"`
+}
+
+// OutputProfile is a config profile for CLI output
+type OutputProfile struct {
+	Name      string `json:"name"`
+	Active    bool   `json:"active"`
+	Current   bool   `json:"current"`
+	AppID     string `json:"app_id,omitempty"`
+	Region    string `json:"region"`
+	Timezone  string `json:"timezone,omitempty"`
+	ConfigDir string `json:"config_dir"`
+}
+
+// OutputProfileList is the profile list response for CLI
+type OutputProfileList struct {
+	Profiles []OutputProfile `json:"profiles"`
+	Active   string          `json:"active"`
+	Count    int             `json:"count"`
+}
+
 // OutputSuccess is a generic success response
 type OutputSuccess struct {
 	Success bool   `json:"success"`
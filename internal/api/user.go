@@ -1,7 +1,5 @@
 package api
 
-import "fmt"
-
 // UserInfo represents the current user's information
 type UserInfo struct {
 	Name      string `json:"name"`
@@ -27,10 +25,6 @@ func (c *Client) GetCurrentUser() (*UserInfo, error) {
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return &resp.Data, nil
 }
 
@@ -54,9 +48,5 @@ func (c *Client) LookupUsers(opts UserLookupOptions) ([]UserContactInfo, error)
 		return nil, err
 	}
 
-	if resp.Code != 0 {
-		return nil, fmt.Errorf("API error (code %d): %s", resp.Code, resp.Msg)
-	}
-
 	return resp.Data.UserList, nil
 }
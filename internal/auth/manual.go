@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yjwong/lark-cli/internal/config"
+)
+
+// pendingLoginTTL is how long a login started with PrepareLogin can be completed
+const pendingLoginTTL = 10 * time.Minute
+
+// codePattern matches a bare authorization code
+var codePattern = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+
+// pendingLogin is saved by PrepareLogin so a later process can complete the
+// login and validate the returned state
+type pendingLogin struct {
+	State       string    `json:"state"`
+	RedirectURI string    `json:"redirect_uri"`
+	Scope       string    `json:"scope"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PreparedLogin describes the authorization URL to visit to complete a
+// scripted login
+type PreparedLogin struct {
+	AuthURL     string    `json:"auth_url"`
+	RedirectURI string    `json:"redirect_uri"`
+	State       string    `json:"state"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func pendingLoginPath() string {
+	return filepath.Join(config.GetProfileDir(), "pending_login.json")
+}
+
+// redirectURI returns the redirect URI registered for the app
+func redirectURI() string {
+	return fmt.Sprintf("http://localhost:%d/callback", config.GetRedirectPort())
+}
+
+// PrepareLogin starts a login to be completed later with LoginOptions.Code.
+// It saves the state for validation and returns the authorization URL.
+func PrepareLogin(opts LoginOptions) (*PreparedLogin, error) {
+	appID, _, err := appCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := generateState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	pending := pendingLogin{
+		State:       state,
+		RedirectURI: redirectURI(),
+		Scope:       requestedScope(opts),
+		CreatedAt:   time.Now(),
+	}
+	data, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pending login: %w", err)
+	}
+	if err := os.WriteFile(pendingLoginPath(), data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to save pending login: %w", err)
+	}
+
+	return &PreparedLogin{
+		AuthURL:     buildAuthorizationURL(appID, pending.RedirectURI, state, pending.Scope),
+		RedirectURI: pending.RedirectURI,
+		State:       state,
+		ExpiresAt:   pending.CreatedAt.Add(pendingLoginTTL),
+	}, nil
+}
+
+// loadPendingLogin returns the login saved by PrepareLogin, or nil if there
+// is none or it has expired
+func loadPendingLogin() *pendingLogin {
+	data, err := os.ReadFile(pendingLoginPath())
+	if err != nil {
+		return nil
+	}
+	var pending pendingLogin
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil
+	}
+	if time.Since(pending.CreatedAt) > pendingLoginTTL {
+		return nil
+	}
+	return &pending
+}
+
+// loginWithCode completes a login from a code or redirect URL obtained
+// outside this process
+func loginWithCode(appID, appSecret, input string) error {
+	pending := loadPendingLogin()
+
+	expectedState := ""
+	uri := redirectURI()
+	if pending != nil {
+		expectedState = pending.State
+		uri = pending.RedirectURI
+	}
+
+	code, err := parseAuthorizationResponse(input, expectedState)
+	if err != nil {
+		return err
+	}
+
+	if err := completeLogin(appID, appSecret, code, uri); err != nil {
+		return err
+	}
+
+	os.Remove(pendingLoginPath())
+	return nil
+}
+
+// loginNoBrowser prints the authorization URL and reads the redirect URL or
+// code from input
+func loginNoBrowser(appID, appSecret, scopeString string, input io.Reader) error {
+	state, err := generateState()
+	if err != nil {
+		return fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	uri := redirectURI()
+	authURL := buildAuthorizationURL(appID, uri, state, scopeString)
+
+	// Prompts go to stderr so stdout stays machine-readable
+	fmt.Fprintf(os.Stderr, "Visit this URL in a browser on any machine:\n\n%s\n\n", authURL)
+	fmt.Fprintf(os.Stderr, "After approving, the browser is redirected to %s, which will\n", uri)
+	fmt.Fprintf(os.Stderr, "fail to load. Copy the full URL from the address bar and paste it here:\n")
+
+	line, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && (err != io.EOF || strings.TrimSpace(line) == "") {
+		return fmt.Errorf("failed to read redirect URL: %w", err)
+	}
+
+	code, err := parseAuthorizationResponse(line, state)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "Authorization code received, exchanging for tokens...")
+	return completeLogin(appID, appSecret, code, uri)
+}
+
+// parseAuthorizationResponse extracts the authorization code from a pasted
+// redirect URL (or its query string) or a bare code. A state in the input
+// must match expectedState; a bare code cannot be checked and is accepted.
+func parseAuthorizationResponse(input, expectedState string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("no redirect URL or authorization code provided")
+	}
+
+	if codePattern.MatchString(input) {
+		return input, nil
+	}
+
+	rawQuery := input
+	if u, err := url.Parse(input); err == nil && (u.Scheme != "" || strings.HasPrefix(input, "/")) {
+		rawQuery = u.RawQuery
+	}
+	rawQuery = strings.TrimPrefix(rawQuery, "?")
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("could not parse redirect URL: %w", err)
+	}
+
+	if errParam := query.Get("error"); errParam != "" {
+		return "", fmt.Errorf("authorization denied: %s", errParam)
+	}
+
+	state := query.Get("state")
+	if expectedState == "" {
+		return "", fmt.Errorf("cannot validate state: no login is pending; run 'lark auth login --print-url' first or pass the bare code")
+	}
+	if state != expectedState {
+		return "", fmt.Errorf("state mismatch: expected %s, got %q", expectedState, state)
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		return "", fmt.Errorf("no authorization code in redirect URL")
+	}
+	return code, nil
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"os/exec"
 	"runtime"
 	"time"
@@ -24,30 +25,16 @@ const (
 	defaultTimeout    = 5 * time.Minute
 )
 
-func getAccountsHost() string {
-	if config.GetRegion() == "feishu" {
-		return "accounts.feishu.cn"
-	}
-	return "accounts.larksuite.com"
-}
-
-func getOpenHost() string {
-	if config.GetRegion() == "feishu" {
-		return "open.feishu.cn"
-	}
-	return "open.larksuite.com"
-}
-
 func getAuthorizationURL() string {
-	return "https://" + getAccountsHost() + authorizationPath
+	return config.GetAccountsBaseURL() + authorizationPath
 }
 
 func getTokenURL() string {
-	return "https://" + getOpenHost() + tokenPath
+	return config.GetOpenBaseURL() + tokenPath
 }
 
 func getTenantTokenURL() string {
-	return "https://" + getOpenHost() + tenantTokenPath
+	return config.GetOpenBaseURL() + tenantTokenPath
 }
 
 // TokenResponse represents the OAuth token response from Lark
@@ -76,6 +63,17 @@ type LoginOptions struct {
 	// ScopeGroups specifies which scope groups to request (e.g., "calendar", "contacts")
 	// If empty, all scopes are requested (default behavior)
 	ScopeGroups []string
+
+	// NoBrowser prints the authorization URL and reads the pasted redirect
+	// URL or code from Input instead of opening a browser and running the
+	// local callback server
+	NoBrowser bool
+	Input     io.Reader
+
+	// Code completes a login non-interactively with a redirect URL or
+	// authorization code obtained beforehand. If PrepareLogin was called,
+	// the state in a redirect URL is validated against the saved one.
+	Code string
 }
 
 // Login performs the OAuth login flow with default options (all scopes)
@@ -83,25 +81,48 @@ func Login() error {
 	return LoginWithOptions(LoginOptions{})
 }
 
-// LoginWithOptions performs the OAuth login flow with the specified options
-func LoginWithOptions(opts LoginOptions) error {
+// appCredentials returns the configured app ID and secret
+func appCredentials() (string, string, error) {
 	appID := config.GetAppID()
 	appSecret := config.GetAppSecret()
 
 	if appID == "" {
-		return fmt.Errorf("app_id not configured. Set it in .lark/config.yaml or LARK_APP_ID env var")
+		return "", "", fmt.Errorf("app_id not configured. Set it in .lark/config.yaml or LARK_APP_ID env var")
 	}
 	if appSecret == "" {
-		return fmt.Errorf("app_secret not configured. Set LARK_APP_SECRET env var")
+		return "", "", fmt.Errorf("app_secret not configured. Set LARK_APP_SECRET env var")
 	}
+	return appID, appSecret, nil
+}
 
-	// Determine which scopes to request
-	var scopeString string
+// requestedScope returns the OAuth scope string for the requested groups
+func requestedScope(opts LoginOptions) string {
 	if len(opts.ScopeGroups) == 0 {
 		// Default: request all scopes
-		scopeString = scopes.GetAllScopeString()
-	} else {
-		scopeString = scopes.GetScopeString(opts.ScopeGroups)
+		return scopes.GetAllScopeString()
+	}
+	return scopes.GetScopeString(opts.ScopeGroups)
+}
+
+// LoginWithOptions performs the OAuth login flow with the specified options
+func LoginWithOptions(opts LoginOptions) error {
+	appID, appSecret, err := appCredentials()
+	if err != nil {
+		return err
+	}
+
+	if opts.Code != "" {
+		return loginWithCode(appID, appSecret, opts.Code)
+	}
+
+	scopeString := requestedScope(opts)
+
+	if opts.NoBrowser {
+		input := opts.Input
+		if input == nil {
+			input = os.Stdin
+		}
+		return loginNoBrowser(appID, appSecret, scopeString, input)
 	}
 
 	// Generate state for CSRF protection
@@ -140,13 +161,21 @@ func LoginWithOptions(opts LoginOptions) error {
 
 	fmt.Println("Authorization code received, exchanging for tokens...")
 
-	// Exchange code for tokens
+	if err := completeLogin(appID, appSecret, code, redirectURI); err != nil {
+		return err
+	}
+
+	fmt.Println("Authentication successful!")
+	return nil
+}
+
+// completeLogin exchanges an authorization code for tokens and stores them
+func completeLogin(appID, appSecret, code, redirectURI string) error {
 	tokenResp, err := exchangeCodeForTokens(appID, appSecret, code, redirectURI)
 	if err != nil {
 		return fmt.Errorf("failed to exchange code: %w", err)
 	}
 
-	// Store tokens
 	store := GetTokenStore()
 	if err := store.Update(
 		tokenResp.AccessToken,
@@ -158,7 +187,6 @@ func LoginWithOptions(opts LoginOptions) error {
 		return fmt.Errorf("failed to save tokens: %w", err)
 	}
 
-	fmt.Println("Authentication successful!")
 	return nil
 }
 
@@ -215,6 +243,9 @@ func EnsureValidToken() error {
 		return RefreshAccessToken()
 	}
 
+	if err := store.LoadError(); err != nil {
+		return fmt.Errorf("failed to load tokens: %w", err)
+	}
 	return fmt.Errorf("no valid authentication, please run 'lark auth login'")
 }
 
@@ -265,7 +296,7 @@ func RefreshTenantToken() error {
 
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := NewHTTPClient(30 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
@@ -358,7 +389,7 @@ func doTokenRequest(reqBody map[string]string) (*TokenResponse, error) {
 
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := NewHTTPClient(30 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/yjwong/lark-cli/internal/config"
 	"github.com/yjwong/lark-cli/internal/scopes"
+	"github.com/yjwong/lark-cli/internal/secrets"
 )
 
 // TokenStore holds the OAuth tokens
@@ -19,6 +21,8 @@ type TokenStore struct {
 	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 	Scope                 string    `json:"scope"`
 	UserID                string    `json:"user_id,omitempty"`
+	path                  string
+	loadErr               error
 	mu                    sync.RWMutex
 }
 
@@ -39,19 +43,35 @@ type TenantTokenStore struct {
 // GetTokenStore returns the singleton token store
 func GetTokenStore() *TokenStore {
 	tokensOnce.Do(func() {
-		tokens = &TokenStore{}
-		tokens.Load()
+		tokens = &TokenStore{path: config.TokensFilePath()}
+		tokens.loadErr = tokens.Load()
 	})
 	return tokens
 }
 
+// LoadProfileTokenStore reads the user tokens of any profile without
+// activating it
+func LoadProfileTokenStore(profile string) (*TokenStore, error) {
+	t := &TokenStore{path: filepath.Join(config.ProfileDir(profile), "tokens.json")}
+	if err := t.Load(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// LoadError returns the error, if any, from reading the tokens file when the
+// store was created (e.g. a missing passphrase for an encrypted file)
+func (t *TokenStore) LoadError() error {
+	return t.loadErr
+}
+
 // Load reads tokens from disk
 func (t *TokenStore) Load() error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	path := config.TokensFilePath()
-	data, err := os.ReadFile(path)
+	path := t.path
+	data, err := secrets.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil // No tokens yet, that's OK
@@ -76,8 +96,8 @@ func (t *TokenStore) Save() error {
 		return fmt.Errorf("failed to marshal tokens: %w", err)
 	}
 
-	path := config.TokensFilePath()
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	path := t.path
+	if err := secrets.WriteFile(path, data); err != nil {
 		return fmt.Errorf("failed to write tokens: %w", err)
 	}
 
@@ -96,7 +116,7 @@ func (t *TokenStore) Clear() error {
 	t.Scope = ""
 	t.UserID = ""
 
-	path := config.TokensFilePath()
+	path := t.path
 	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove tokens file: %w", err)
 	}
@@ -236,7 +256,7 @@ func (t *TenantTokenStore) Load() error {
 	defer t.mu.Unlock()
 
 	path := config.TenantTokensFilePath()
-	data, err := os.ReadFile(path)
+	data, err := secrets.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil // No tokens yet, that's OK
@@ -262,7 +282,7 @@ func (t *TenantTokenStore) Save() error {
 	}
 
 	path := config.TenantTokensFilePath()
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	if err := secrets.WriteFile(path, data); err != nil {
 		return fmt.Errorf("failed to write tenant tokens: %w", err)
 	}
 
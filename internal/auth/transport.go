@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+)
+
+// transport is the HTTP transport used for every request to Lark.
+// nil means http.DefaultTransport.
+var transport http.RoundTripper
+
+// SetTransport overrides the HTTP transport used for all Lark requests,
+// e.g. to route traffic to an in-process fake server. Pass nil to restore
+// the default transport.
+func SetTransport(rt http.RoundTripper) {
+	transport = rt
+}
+
+// NewHTTPClient returns an HTTP client that uses the configured transport
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
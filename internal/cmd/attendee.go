@@ -57,7 +57,7 @@ Examples:
 		if addAttendeeSelf {
 			currentUser, err := client.GetCurrentUser()
 			if err != nil {
-				output.Fatalf("USER_ERROR", "Failed to get current user: %v", err)
+				output.Fatalf("USER_ERROR", "Failed to get current user: %w", err)
 			}
 			attendees = append(attendees, api.Attendee{
 				Type:       "user",
@@ -170,7 +170,7 @@ Examples:
 		if removeAttendeeSelf {
 			currentUser, err := client.GetCurrentUser()
 			if err != nil {
-				output.Fatalf("USER_ERROR", "Failed to get current user: %v", err)
+				output.Fatalf("USER_ERROR", "Failed to get current user: %w", err)
 			}
 
 			// List attendees to find our attendee_id
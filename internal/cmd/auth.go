@@ -2,18 +2,26 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/yjwong/lark-cli/internal/api"
 	"github.com/yjwong/lark-cli/internal/auth"
+	"github.com/yjwong/lark-cli/internal/config"
 	"github.com/yjwong/lark-cli/internal/output"
 	"github.com/yjwong/lark-cli/internal/scopes"
 )
 
 var (
-	loginScopes string
-	loginAdd    bool
+	loginScopes    string
+	loginAdd       bool
+	loginNoBrowser bool
+	loginPrintURL  bool
+	loginCodeFile  string
+	loginCodeEnv   string
+	statusAll      bool
 )
 
 var authCmd = &cobra.Command{
@@ -32,11 +40,23 @@ scope groups for a minimal permission setup.
 
 Scope groups: calendar, contacts, documents, messages, mail, minutes
 
+On machines without a browser (SSH, containers), use --no-browser: the
+authorization URL is printed, and after approving it elsewhere you paste the
+URL the browser was redirected to (or just the code). The state parameter in
+a pasted URL is validated.
+
+For scripts and CI, split the login in two steps. --print-url saves the
+state and prints the URL as JSON; the redirect URL or code is then supplied
+with --code-file (use - for stdin) or --code-env within 10 minutes.
+
 Examples:
   lark auth login                           # All permissions (default)
   lark auth login --scopes calendar         # Only calendar permissions
   lark auth login --scopes calendar,contacts # Calendar and contacts
-  lark auth login --add --scopes messages   # Add messaging to existing permissions`,
+  lark auth login --add --scopes messages   # Add messaging to existing permissions
+  lark auth login --no-browser              # Paste the redirect URL on stdin
+  lark auth login --print-url               # Step 1 of a scripted login
+  lark auth login --code-env LARK_AUTH_CODE # Step 2, code or redirect URL from env`,
 	Run: func(cmd *cobra.Command, args []string) {
 		opts := auth.LoginOptions{}
 
@@ -56,6 +76,34 @@ Examples:
 		}
 		// If loginScopes is empty, opts.ScopeGroups remains nil, triggering default (all scopes)
 
+		if loginCodeFile != "" && loginCodeEnv != "" {
+			output.Fatal("VALIDATION_ERROR", fmt.Errorf("--code-file and --code-env are mutually exclusive"))
+		}
+
+		if loginPrintURL {
+			prepared, err := auth.PrepareLogin(opts)
+			if err != nil {
+				output.Fatal("AUTH_ERROR", err)
+			}
+			output.JSON(prepared)
+			return
+		}
+
+		switch {
+		case loginCodeFile != "":
+			code, err := readLoginCodeFile(loginCodeFile)
+			if err != nil {
+				output.Fatal("VALIDATION_ERROR", err)
+			}
+			opts.Code = code
+		case loginCodeEnv != "":
+			opts.Code = os.Getenv(loginCodeEnv)
+			if strings.TrimSpace(opts.Code) == "" {
+				output.Fatal("VALIDATION_ERROR", fmt.Errorf("environment variable %s is empty", loginCodeEnv))
+			}
+		}
+		opts.NoBrowser = loginNoBrowser
+
 		if err := auth.LoginWithOptions(opts); err != nil {
 			output.Fatal("AUTH_ERROR", err)
 		}
@@ -63,6 +111,25 @@ Examples:
 	},
 }
 
+// readLoginCodeFile reads a redirect URL or code from a file, or stdin for "-"
+func readLoginCodeFile(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read code: %w", err)
+	}
+	code := strings.TrimSpace(string(data))
+	if code == "" {
+		return "", fmt.Errorf("no code in %s", path)
+	}
+	return code, nil
+}
+
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Logout from Lark",
@@ -78,8 +145,16 @@ var logoutCmd = &cobra.Command{
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show authentication status",
-	Long:  "Display current authentication status, token expiry, and granted permissions",
+	Long: `Display current authentication status, token expiry, and granted permissions.
+
+With --all, report the stored tokens of every profile. Tokens of inactive
+profiles are not refreshed.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if statusAll {
+			output.JSON(allProfilesAuthStatus())
+			return
+		}
+
 		store := auth.GetTokenStore()
 
 		status := api.OutputAuthStatus{
@@ -105,6 +180,50 @@ var statusCmd = &cobra.Command{
 	},
 }
 
+// allProfilesAuthStatus reports the token state of every profile
+func allProfilesAuthStatus() api.OutputProfileAuthStatusList {
+	names, err := config.ListProfiles()
+	if err != nil {
+		output.Fatal("CONFIG_ERROR", err)
+	}
+
+	result := api.OutputProfileAuthStatusList{
+		Profiles: make([]api.OutputProfileAuthStatus, 0, len(names)),
+	}
+	for _, name := range names {
+		entry := api.OutputProfileAuthStatus{
+			Profile: name,
+			Active:  name == config.GetProfile(),
+		}
+
+		cfg, err := config.LoadProfile(name)
+		if err != nil {
+			entry.Error = err.Error()
+			result.Profiles = append(result.Profiles, entry)
+			continue
+		}
+		entry.AppID = cfg.AppID
+		entry.Region = cfg.Region
+
+		store, err := auth.LoadProfileTokenStore(name)
+		if err != nil {
+			entry.Error = err.Error()
+			result.Profiles = append(result.Profiles, entry)
+			continue
+		}
+		entry.Authenticated = store.IsValid()
+		entry.ExpiresAt = store.GetExpiresAt()
+		entry.CanRefresh = store.CanRefresh()
+		if entry.Authenticated {
+			entry.GrantedGroups = store.GetGrantedGroupsList()
+		}
+
+		result.Profiles = append(result.Profiles, entry)
+	}
+
+	return result
+}
+
 var scopesCmd = &cobra.Command{
 	Use:   "scopes",
 	Short: "List available scope groups",
@@ -138,6 +257,11 @@ var scopesCmd = &cobra.Command{
 func init() {
 	loginCmd.Flags().StringVar(&loginScopes, "scopes", "", "Comma-separated scope groups (calendar,contacts,documents,messages,mail,minutes)")
 	loginCmd.Flags().BoolVar(&loginAdd, "add", false, "Add to existing permissions (incremental authorization)")
+	loginCmd.Flags().BoolVar(&loginNoBrowser, "no-browser", false, "Print the authorization URL and read the redirect URL or code from stdin")
+	loginCmd.Flags().BoolVar(&loginPrintURL, "print-url", false, "Print the authorization URL as JSON and save state for --code-file/--code-env")
+	loginCmd.Flags().StringVar(&loginCodeFile, "code-file", "", "Read the redirect URL or code from a file (- for stdin)")
+	loginCmd.Flags().StringVar(&loginCodeEnv, "code-env", "", "Read the redirect URL or code from an environment variable")
+	statusCmd.Flags().BoolVar(&statusAll, "all", false, "Show status for every profile")
 
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(logoutCmd)
@@ -22,6 +22,9 @@ var calCmd = &cobra.Command{
 // and exits with a helpful error message if not
 func validateScopeGroup(groupName string) {
 	store := auth.GetTokenStore()
+	if err := store.LoadError(); err != nil {
+		output.Fatal("AUTH_ERROR", fmt.Errorf("failed to load tokens: %w", err))
+	}
 
 	// First check if authenticated at all
 	if !store.IsValid() && !store.CanRefresh() {
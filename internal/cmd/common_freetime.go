@@ -63,12 +63,12 @@ Examples:
 		// Parse time range
 		startTime, err := timex.Parse(commonFreetimeFrom, loc)
 		if err != nil {
-			output.Fatalf("PARSE_ERROR", "Failed to parse --from: %v", err)
+			output.Fatalf("PARSE_ERROR", "Failed to parse --from: %w", err)
 		}
 
 		endTime, err := timex.Parse(commonFreetimeTo, loc)
 		if err != nil {
-			output.Fatalf("PARSE_ERROR", "Failed to parse --to: %v", err)
+			output.Fatalf("PARSE_ERROR", "Failed to parse --to: %w", err)
 		}
 
 		// If input doesn't contain a specific time, use start/end of day
@@ -67,7 +67,7 @@ Examples:
 		// Parse start time
 		startTime, err := timex.Parse(createStart, loc)
 		if err != nil {
-			output.Fatalf("PARSE_ERROR", "Failed to parse --start: %v", err)
+			output.Fatalf("PARSE_ERROR", "Failed to parse --start: %w", err)
 		}
 
 		// Parse end time or calculate from duration
@@ -75,12 +75,12 @@ Examples:
 		if createEnd != "" {
 			endTime, err = timex.Parse(createEnd, loc)
 			if err != nil {
-				output.Fatalf("PARSE_ERROR", "Failed to parse --end: %v", err)
+				output.Fatalf("PARSE_ERROR", "Failed to parse --end: %w", err)
 			}
 		} else {
 			duration, err := timex.ParseDuration(createDuration)
 			if err != nil {
-				output.Fatalf("PARSE_ERROR", "Failed to parse --duration: %v", err)
+				output.Fatalf("PARSE_ERROR", "Failed to parse --duration: %w", err)
 			}
 			endTime = startTime.Add(duration)
 		}
@@ -160,7 +160,7 @@ Examples:
 		if !createExcludeSelf {
 			currentUser, err := client.GetCurrentUser()
 			if err != nil {
-				output.Fatalf("USER_ERROR", "Failed to get current user: %v", err)
+				output.Fatalf("USER_ERROR", "Failed to get current user: %w", err)
 			}
 			attendees = append(attendees, api.Attendee{
 				Type:   "user",
@@ -172,7 +172,7 @@ Examples:
 		if len(createAttendees) > 0 {
 			parsedAttendees, err := parseAttendees(client, createAttendees)
 			if err != nil {
-				output.Fatalf("ATTENDEE_ERROR", "Failed to parse attendees: %v", err)
+				output.Fatalf("ATTENDEE_ERROR", "Failed to parse attendees: %w", err)
 			}
 			attendees = append(attendees, parsedAttendees...)
 		}
@@ -182,7 +182,7 @@ Examples:
 			notify := !createNoNotify
 			addedAttendees, err := client.CreateEventAttendees(cal.CalendarID, event.EventID, attendees, notify)
 			if err != nil {
-				output.Fatalf("ATTENDEE_ERROR", "Failed to add attendees: %v", err)
+				output.Fatalf("ATTENDEE_ERROR", "Failed to add attendees: %w", err)
 			}
 			event.Attendees = addedAttendees
 		}
@@ -44,12 +44,12 @@ Examples:
 		// Parse time range
 		startTime, err := timex.Parse(freebusyFrom, loc)
 		if err != nil {
-			output.Fatalf("PARSE_ERROR", "Failed to parse --from: %v", err)
+			output.Fatalf("PARSE_ERROR", "Failed to parse --from: %w", err)
 		}
 
 		endTime, err := timex.Parse(freebusyTo, loc)
 		if err != nil {
-			output.Fatalf("PARSE_ERROR", "Failed to parse --to: %v", err)
+			output.Fatalf("PARSE_ERROR", "Failed to parse --to: %w", err)
 		}
 
 		// If input doesn't contain a specific time, use start/end of day
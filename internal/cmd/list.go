@@ -65,7 +65,7 @@ Examples:
 			if listFrom != "" {
 				startTime, err = timex.Parse(listFrom, loc)
 				if err != nil {
-					output.Fatalf("PARSE_ERROR", "Failed to parse --from: %v", err)
+					output.Fatalf("PARSE_ERROR", "Failed to parse --from: %w", err)
 				}
 				startTime = timex.StartOfDay(startTime)
 			} else {
@@ -75,7 +75,7 @@ Examples:
 			if listTo != "" {
 				endTime, err = timex.Parse(listTo, loc)
 				if err != nil {
-					output.Fatalf("PARSE_ERROR", "Failed to parse --to: %v", err)
+					output.Fatalf("PARSE_ERROR", "Failed to parse --to: %w", err)
 				}
 				endTime = timex.EndOfDay(endTime)
 			} else {
@@ -254,14 +254,14 @@ Examples:
 		// Create output file
 		outFile, err := os.Create(msgResourceOutput)
 		if err != nil {
-			output.Fatalf("FILE_ERROR", "failed to create output file: %v", err)
+			output.Fatalf("FILE_ERROR", "failed to create output file: %w", err)
 		}
 		defer outFile.Close()
 
 		// Copy data to file
 		bytesWritten, err := io.Copy(outFile, body)
 		if err != nil {
-			output.Fatalf("FILE_ERROR", "failed to write file: %v", err)
+			output.Fatalf("FILE_ERROR", "failed to write file: %w", err)
 		}
 
 		// Output result
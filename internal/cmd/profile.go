@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yjwong/lark-cli/internal/api"
+	"github.com/yjwong/lark-cli/internal/config"
+	"github.com/yjwong/lark-cli/internal/output"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Config profile commands",
+	Long: `Manage named profiles, each with its own app credentials, region,
+default timezone and tokens.
+
+The active profile is chosen by --profile, then LARK_PROFILE, then the profile
+saved with 'lark profile use', then "default". The default profile is stored
+directly in LARK_CONFIG_DIR; named profiles live in LARK_CONFIG_DIR/profiles/<name>.`,
+}
+
+// --- profile list ---
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Run: func(cmd *cobra.Command, args []string) {
+		names, err := config.ListProfiles()
+		if err != nil {
+			output.Fatal("CONFIG_ERROR", err)
+		}
+
+		current := config.GetCurrentProfile()
+		profiles := make([]api.OutputProfile, 0, len(names))
+		for _, name := range names {
+			p := api.OutputProfile{
+				Name:      name,
+				Active:    name == config.GetProfile(),
+				Current:   name == current,
+				ConfigDir: config.ProfileDir(name),
+			}
+			if c, err := config.LoadProfile(name); err == nil {
+				p.AppID = c.AppID
+				p.Region = c.Region
+				p.Timezone = c.Defaults.Timezone
+			}
+			profiles = append(profiles, p)
+		}
+
+		output.JSON(api.OutputProfileList{
+			Profiles: profiles,
+			Active:   config.GetProfile(),
+			Count:    len(profiles),
+		})
+	},
+}
+
+// --- profile use ---
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the default profile",
+	Long: `Save the profile used when neither --profile nor LARK_PROFILE is set.
+
+Examples:
+  lark profile use work
+  lark profile use default`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		if err := config.ValidateProfileName(name); err != nil {
+			output.Fatal("VALIDATION_ERROR", err)
+		}
+		if !config.ProfileExists(name) {
+			output.Fatal("VALIDATION_ERROR", fmt.Errorf("profile %q does not exist", name))
+		}
+
+		if err := config.SetCurrentProfile(name); err != nil {
+			output.Fatal("CONFIG_ERROR", err)
+		}
+		output.Success(fmt.Sprintf("Now using profile %q", name))
+	},
+}
+
+// --- profile add ---
+
+var (
+	profileAddAppID     string
+	profileAddAppSecret string
+	profileAddRegion    string
+	profileAddTimezone  string
+	profileAddUse       bool
+)
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Create a profile",
+	Long: `Create a named profile with its own config file and tokens.
+
+The app secret can be stored in the profile config (--app-secret) or supplied
+through LARK_<NAME>_APP_SECRET, where <NAME> is the upper-cased profile name
+with '-' replaced by '_'. LARK_<NAME>_APP_ID likewise overrides the app ID.
+
+Examples:
+  lark profile add work --app-id cli_xxx --region lark
+  lark profile add cn --app-id cli_yyy --region feishu --timezone Asia/Shanghai --use
+  lark --profile cn auth login`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		switch profileAddRegion {
+		case "", "lark", "feishu":
+		default:
+			output.Fatal("VALIDATION_ERROR", fmt.Errorf("invalid region %q: must be lark or feishu", profileAddRegion))
+		}
+
+		settings := config.ProfileSettings{
+			AppID:     profileAddAppID,
+			AppSecret: profileAddAppSecret,
+			Region:    profileAddRegion,
+			Timezone:  profileAddTimezone,
+		}
+		if err := config.CreateProfile(name, settings); err != nil {
+			output.Fatal("VALIDATION_ERROR", err)
+		}
+
+		if profileAddUse {
+			if err := config.SetCurrentProfile(name); err != nil {
+				output.Fatal("CONFIG_ERROR", err)
+			}
+		}
+
+		output.JSON(map[string]interface{}{
+			"success":    true,
+			"profile":    name,
+			"config_dir": config.ProfileDir(name),
+			"current":    profileAddUse,
+		})
+	},
+}
+
+// --- profile remove ---
+
+var profileRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Delete a profile",
+	Long: `Delete a named profile, including its stored tokens.
+
+If it was the profile saved with 'lark profile use', the default profile
+becomes current again.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.RemoveProfile(args[0]); err != nil {
+			output.Fatal("VALIDATION_ERROR", err)
+		}
+		output.Success(fmt.Sprintf("Removed profile %q", args[0]))
+	},
+}
+
+func init() {
+	profileAddCmd.Flags().StringVar(&profileAddAppID, "app-id", "", "App ID")
+	profileAddCmd.Flags().StringVar(&profileAddAppSecret, "app-secret", "", "App secret (stored in the profile config)")
+	profileAddCmd.Flags().StringVar(&profileAddRegion, "region", "", "API region: lark or feishu (default lark)")
+	profileAddCmd.Flags().StringVar(&profileAddTimezone, "timezone", "", "Default timezone (e.g. Asia/Shanghai)")
+	profileAddCmd.Flags().BoolVar(&profileAddUse, "use", false, "Make the new profile current")
+
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileAddCmd)
+	profileCmd.AddCommand(profileRemoveCmd)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/yjwong/lark-cli/internal/config"
@@ -44,8 +45,30 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// profileFlag is the global --profile flag. It is read from os.Args before
+// cobra parses flags, because config must be loaded first.
+var profileFlag string
+
+// profileFromArgs returns the value of --profile from the command line
+func profileFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(arg, "--profile="); ok {
+			return v
+		}
+		if arg == "--profile" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
 // Execute runs the root command
 func Execute() {
+	config.SetProfile(profileFromArgs(os.Args[1:]))
+
 	// Initialize config, but don't fail for version command
 	if err := config.Init(); err != nil {
 		// Allow version command to run without config
@@ -62,6 +85,8 @@ func Execute() {
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Config profile to use (overrides LARK_PROFILE and 'lark profile use')")
+
 	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(bitableCmd)
 	rootCmd.AddCommand(calCmd)
@@ -71,6 +96,8 @@ func init() {
 	rootCmd.AddCommand(mailCmd)
 	rootCmd.AddCommand(minutesCmd)
 	rootCmd.AddCommand(msgCmd)
+	rootCmd.AddCommand(profileCmd)
+	rootCmd.AddCommand(secretsCmd)
 	rootCmd.AddCommand(sheetCmd)
 	rootCmd.AddCommand(versionCmd)
 }
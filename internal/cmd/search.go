@@ -48,7 +48,7 @@ Examples:
 		if searchFrom != "" {
 			startTime, err = timex.Parse(searchFrom, loc)
 			if err != nil {
-				output.Fatalf("PARSE_ERROR", "Failed to parse --from: %v", err)
+				output.Fatalf("PARSE_ERROR", "Failed to parse --from: %w", err)
 			}
 		} else {
 			// Default: 30 days ago
@@ -58,7 +58,7 @@ Examples:
 		if searchTo != "" {
 			endTime, err = timex.Parse(searchTo, loc)
 			if err != nil {
-				output.Fatalf("PARSE_ERROR", "Failed to parse --to: %v", err)
+				output.Fatalf("PARSE_ERROR", "Failed to parse --to: %w", err)
 			}
 		} else {
 			// Default: 30 days ahead
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/yjwong/lark-cli/internal/config"
+	"github.com/yjwong/lark-cli/internal/output"
+	"github.com/yjwong/lark-cli/internal/secrets"
+)
+
+// secretFileNames are the files in each profile directory that hold
+// credentials: user tokens, tenant tokens and IMAP credentials
+var secretFileNames = []string{"tokens.json", "tenant_tokens.json", "mail.json"}
+
+var (
+	secretsMigrateRekey  bool
+	secretsMigrateDryRun bool
+)
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Secret storage commands",
+	Long: `Manage how tokens and mail credentials are stored on disk.
+
+The storage backend is set with secrets.backend in config.yaml or
+LARK_SECRET_BACKEND:
+  encrypted  AES-256-GCM (default). The key is derived from
+             LARK_SECRET_PASSPHRASE if set, otherwise from a random key file
+             (secrets.key_file or LARK_SECRET_KEY_FILE, default
+             <config dir>/secret.key) created on first use.
+  plaintext  Unencrypted JSON, as written by earlier versions.`,
+}
+
+// --- secrets status ---
+
+var secretsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show how each secret file is stored",
+	Run: func(cmd *cobra.Command, args []string) {
+		backend, results := migrateSecretFiles(false, true)
+
+		type fileStatus struct {
+			Path           string `json:"path"`
+			StoredAs       string `json:"stored_as"`
+			NeedsMigration bool   `json:"needs_migration"`
+		}
+		files := make([]fileStatus, 0, len(results))
+		for _, r := range results {
+			files = append(files, fileStatus{
+				Path:           r.Path,
+				StoredAs:       r.From,
+				NeedsMigration: r.Status == "migrated",
+			})
+		}
+
+		output.JSON(map[string]interface{}{
+			"backend": backend,
+			"files":   files,
+		})
+	},
+}
+
+// --- secrets migrate ---
+
+var secretsMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Rewrite secret files with the configured backend",
+	Long: `Rewrite the token and mail credential files of every profile in place
+using the configured backend. Files already in that format are skipped.
+
+To change the passphrase, set LARK_SECRET_OLD_PASSPHRASE to the old one and
+LARK_SECRET_PASSPHRASE to the new one, then run with --rekey.
+
+Examples:
+  lark secrets migrate                            # Encrypt existing plaintext files
+  lark secrets migrate --dry-run                  # Show what would change
+  LARK_SECRET_PASSPHRASE=... lark secrets migrate # Switch from key file to passphrase
+  LARK_SECRET_BACKEND=plaintext lark secrets migrate # Decrypt back to plaintext`,
+	Run: func(cmd *cobra.Command, args []string) {
+		backend, files := migrateSecretFiles(secretsMigrateRekey, secretsMigrateDryRun)
+
+		migrated := 0
+		for _, f := range files {
+			if f.Status == "migrated" {
+				migrated++
+			}
+		}
+
+		output.JSON(map[string]interface{}{
+			"backend":  backend,
+			"files":    files,
+			"migrated": migrated,
+			"dry_run":  secretsMigrateDryRun,
+		})
+	},
+}
+
+// migrateSecretFiles migrates every profile's existing secret files and
+// returns the backend name and per-file results
+func migrateSecretFiles(rekey, dryRun bool) (string, []secrets.MigrateResult) {
+	backend, err := secrets.Current()
+	if err != nil {
+		output.Fatal("CONFIG_ERROR", err)
+	}
+
+	profiles, err := config.ListProfiles()
+	if err != nil {
+		output.Fatal("CONFIG_ERROR", err)
+	}
+
+	files := []secrets.MigrateResult{}
+	for _, profile := range profiles {
+		for _, name := range secretFileNames {
+			result, err := secrets.Migrate(filepath.Join(config.ProfileDir(profile), name), rekey, dryRun)
+			if err != nil {
+				output.Fatal("SECRETS_ERROR", fmt.Errorf("profile %s: %w", profile, err))
+			}
+			if result.Status != "missing" {
+				files = append(files, result)
+			}
+		}
+	}
+
+	return backend.Name(), files
+}
+
+func init() {
+	secretsMigrateCmd.Flags().BoolVar(&secretsMigrateRekey, "rekey", false, "Re-encrypt files that are already encrypted (e.g. after changing the passphrase)")
+	secretsMigrateCmd.Flags().BoolVar(&secretsMigrateDryRun, "dry-run", false, "Report what would change without writing files")
+
+	secretsCmd.AddCommand(secretsStatusCmd)
+	secretsCmd.AddCommand(secretsMigrateCmd)
+}
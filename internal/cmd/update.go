@@ -70,7 +70,7 @@ Examples:
 		if updateStart != "" || updateEnd != "" {
 			existingEvent, err := client.GetEvent(cal.CalendarID, eventID)
 			if err != nil {
-				output.Fatalf("API_ERROR", "Failed to fetch existing event: %v", err)
+				output.Fatalf("API_ERROR", "Failed to fetch existing event: %w", err)
 			}
 
 			var currentStart, currentEnd time.Time
@@ -90,12 +90,12 @@ Examples:
 			if updateStart != "" {
 				newStart, err = timex.Parse(updateStart, loc)
 				if err != nil {
-					output.Fatalf("PARSE_ERROR", "Failed to parse --start: %v", err)
+					output.Fatalf("PARSE_ERROR", "Failed to parse --start: %w", err)
 				}
 				if updateEnd != "" {
 					newEnd, err = timex.Parse(updateEnd, loc)
 					if err != nil {
-						output.Fatalf("PARSE_ERROR", "Failed to parse --end: %v", err)
+						output.Fatalf("PARSE_ERROR", "Failed to parse --end: %w", err)
 					}
 				} else {
 					// Calculate end time based on original duration
@@ -104,7 +104,7 @@ Examples:
 			} else if updateEnd != "" {
 				newEnd, err = timex.Parse(updateEnd, loc)
 				if err != nil {
-					output.Fatalf("PARSE_ERROR", "Failed to parse --end: %v", err)
+					output.Fatalf("PARSE_ERROR", "Failed to parse --end: %w", err)
 				}
 				// Keep the original start time
 				newStart = currentStart
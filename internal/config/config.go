@@ -20,6 +20,14 @@ type Config struct {
 	OAuth struct {
 		RedirectPort int `mapstructure:"redirect_port"`
 	} `mapstructure:"oauth"`
+	Endpoints struct {
+		OpenBaseURL     string `mapstructure:"open_base_url"`
+		AccountsBaseURL string `mapstructure:"accounts_base_url"`
+	} `mapstructure:"endpoints"`
+	Secrets struct {
+		Backend string `mapstructure:"backend"`
+		KeyFile string `mapstructure:"key_file"`
+	} `mapstructure:"secrets"`
 	CustomEmojis map[string]string `mapstructure:"custom_emojis"`
 }
 
@@ -29,7 +37,8 @@ var (
 	rootDir string
 )
 
-// GetConfigDir returns the .lark directory path
+// GetConfigDir returns the .lark directory path. Profile-specific files
+// belong in GetProfileDir instead.
 func GetConfigDir() string {
 	return cfgDir
 }
@@ -57,27 +66,15 @@ func Init() error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(cfgDir)
+	name, err := resolveProfile()
+	if err != nil {
+		return err
+	}
+	activeProfile = name
 
-	// Set defaults
-	viper.SetDefault("region", "lark")
-	viper.SetDefault("defaults.timezone", "Asia/Singapore")
-	viper.SetDefault("defaults.reminder_minutes", 15)
-	viper.SetDefault("oauth.redirect_port", 9999)
-
-	// Environment variable bindings
-	viper.SetEnvPrefix("LARK")
-	viper.BindEnv("app_id", "LARK_APP_ID")
-	viper.BindEnv("app_secret", "LARK_APP_SECRET")
-
-	// Read config file (if exists)
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return fmt.Errorf("error reading config: %w", err)
-		}
-		// Config file not found is OK, we'll use defaults and env vars
+	configure(viper.GetViper(), name)
+	if err := readConfig(viper.GetViper()); err != nil {
+		return err
 	}
 
 	cfg = &Config{}
@@ -96,6 +93,50 @@ func Get() *Config {
 	return cfg
 }
 
+// configure sets the config file location, defaults and environment
+// bindings for a profile
+func configure(v *viper.Viper, profile string) {
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(ProfileDir(profile))
+
+	// Set defaults
+	v.SetDefault("region", "lark")
+	v.SetDefault("defaults.timezone", "Asia/Singapore")
+	v.SetDefault("defaults.reminder_minutes", 15)
+	v.SetDefault("oauth.redirect_port", 9999)
+	v.SetDefault("secrets.backend", "encrypted")
+
+	// Environment variable bindings. App credentials are per profile:
+	// LARK_APP_ID for the default profile, LARK_<PROFILE>_APP_ID otherwise.
+	prefix := profileEnvPrefix(profile)
+	v.SetEnvPrefix("LARK")
+	v.BindEnv("app_id", prefix+"_APP_ID")
+	v.BindEnv("app_secret", prefix+"_APP_SECRET")
+	v.BindEnv("endpoints.open_base_url", "LARK_OPEN_BASE_URL")
+	v.BindEnv("endpoints.accounts_base_url", "LARK_ACCOUNTS_BASE_URL")
+	v.BindEnv("secrets.backend", "LARK_SECRET_BACKEND")
+	v.BindEnv("secrets.key_file", "LARK_SECRET_KEY_FILE")
+}
+
+// newViper returns a viper instance configured for a profile
+func newViper(profile string) *viper.Viper {
+	v := viper.New()
+	configure(v, profile)
+	return v
+}
+
+// readConfig reads the config file, if one exists
+func readConfig(v *viper.Viper) error {
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return fmt.Errorf("error reading config: %w", err)
+		}
+		// Config file not found is OK, we'll use defaults and env vars
+	}
+	return nil
+}
+
 // GetAppID returns the app ID from config or environment
 func GetAppID() string {
 	return viper.GetString("app_id")
@@ -108,7 +149,12 @@ func GetAppSecret() string {
 
 // GetRegion returns the API/auth region: lark (default) or feishu
 func GetRegion() string {
-	region := strings.ToLower(strings.TrimSpace(viper.GetString("region")))
+	return normalizeRegion(viper.GetString("region"))
+}
+
+// normalizeRegion maps a configured region to lark or feishu
+func normalizeRegion(region string) string {
+	region = strings.ToLower(strings.TrimSpace(region))
 	switch region {
 	case "feishu":
 		return "feishu"
@@ -119,6 +165,30 @@ func GetRegion() string {
 	}
 }
 
+// GetOpenBaseURL returns the open platform base URL (scheme and host, without
+// the /open-apis prefix). It can be overridden to point at a mock server.
+func GetOpenBaseURL() string {
+	if u := strings.TrimRight(viper.GetString("endpoints.open_base_url"), "/"); u != "" {
+		return u
+	}
+	if GetRegion() == "feishu" {
+		return "https://open.feishu.cn"
+	}
+	return "https://open.larksuite.com"
+}
+
+// GetAccountsBaseURL returns the accounts (OAuth authorization) base URL.
+// It can be overridden to point at a mock server.
+func GetAccountsBaseURL() string {
+	if u := strings.TrimRight(viper.GetString("endpoints.accounts_base_url"), "/"); u != "" {
+		return u
+	}
+	if GetRegion() == "feishu" {
+		return "https://accounts.feishu.cn"
+	}
+	return "https://accounts.larksuite.com"
+}
+
 // GetTimezone returns the default timezone
 func GetTimezone() string {
 	return viper.GetString("defaults.timezone")
@@ -131,12 +201,27 @@ func GetRedirectPort() int {
 
 // TokensFilePath returns the path to the tokens file
 func TokensFilePath() string {
-	return filepath.Join(cfgDir, "tokens.json")
+	return filepath.Join(GetProfileDir(), "tokens.json")
 }
 
 // TenantTokensFilePath returns the path to the tenant tokens file
 func TenantTokensFilePath() string {
-	return filepath.Join(cfgDir, "tenant_tokens.json")
+	return filepath.Join(GetProfileDir(), "tenant_tokens.json")
+}
+
+// GetSecretsBackend returns the storage backend for token and credential
+// files: encrypted (default) or plaintext
+func GetSecretsBackend() string {
+	return strings.ToLower(strings.TrimSpace(viper.GetString("secrets.backend")))
+}
+
+// GetSecretsKeyFile returns the path of the key file used by the encrypted
+// secrets backend when no passphrase is set. It is shared by all profiles.
+func GetSecretsKeyFile() string {
+	if path := viper.GetString("secrets.key_file"); path != "" {
+		return path
+	}
+	return filepath.Join(cfgDir, "secret.key")
 }
 
 // GetCustomEmojis returns the custom emoji mappings
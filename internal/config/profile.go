@@ -0,0 +1,241 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultProfile is the profile stored directly in the config directory
+const DefaultProfile = "default"
+
+// currentProfileFile records the profile selected with `lark profile use`
+const currentProfileFile = "current_profile"
+
+var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+var (
+	profileOverride string
+	activeProfile   = DefaultProfile
+)
+
+// SetProfile selects the profile to load on Init, taking precedence over
+// LARK_PROFILE and the saved current profile
+func SetProfile(name string) {
+	profileOverride = name
+}
+
+// GetProfile returns the name of the active profile
+func GetProfile() string {
+	return activeProfile
+}
+
+// GetProfileDir returns the directory holding the active profile's config
+// and token files
+func GetProfileDir() string {
+	return ProfileDir(activeProfile)
+}
+
+// ProfileDir returns the directory for the named profile. The default
+// profile lives in the config directory itself; named profiles live under
+// profiles/<name>.
+func ProfileDir(name string) string {
+	if name == "" || name == DefaultProfile {
+		return cfgDir
+	}
+	return filepath.Join(cfgDir, "profiles", name)
+}
+
+// ValidateProfileName checks that a profile name is safe to use as a directory name
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ProfileExists reports whether the named profile has been created
+func ProfileExists(name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+	info, err := os.Stat(ProfileDir(name))
+	return err == nil && info.IsDir()
+}
+
+// ListProfiles returns the default profile followed by named profiles in
+// alphabetical order
+func ListProfiles() ([]string, error) {
+	profiles := []string{DefaultProfile}
+
+	entries, err := os.ReadDir(filepath.Join(cfgDir, "profiles"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return profiles, nil
+		}
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && profileNamePattern.MatchString(e.Name()) && e.Name() != DefaultProfile {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return append(profiles, names...), nil
+}
+
+// GetCurrentProfile returns the profile saved with `lark profile use`
+func GetCurrentProfile() string {
+	data, err := os.ReadFile(filepath.Join(cfgDir, currentProfileFile))
+	if err != nil {
+		return DefaultProfile
+	}
+	if name := strings.TrimSpace(string(data)); name != "" {
+		return name
+	}
+	return DefaultProfile
+}
+
+// SetCurrentProfile saves the profile used when neither --profile nor
+// LARK_PROFILE is given
+func SetCurrentProfile(name string) error {
+	path := filepath.Join(cfgDir, currentProfileFile)
+	if name == DefaultProfile {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to reset current profile: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(name+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to save current profile: %w", err)
+	}
+	return nil
+}
+
+// resolveProfile picks the active profile: --profile, then LARK_PROFILE,
+// then the saved current profile
+func resolveProfile() (string, error) {
+	name := profileOverride
+	if name == "" {
+		name = os.Getenv("LARK_PROFILE")
+	}
+	if name == "" {
+		name = GetCurrentProfile()
+	}
+
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	if !ProfileExists(name) {
+		return "", fmt.Errorf("profile %q does not exist; create it with 'lark profile add %s'", name, name)
+	}
+	return name, nil
+}
+
+// profileEnvPrefix returns the prefix of the app credential environment
+// variables for a profile: LARK for the default profile, LARK_<NAME> otherwise
+func profileEnvPrefix(name string) string {
+	if name == DefaultProfile {
+		return "LARK"
+	}
+	return "LARK_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// ProfileSettings is the subset of a profile's configuration that
+// `lark profile add` writes
+type ProfileSettings struct {
+	AppID     string
+	AppSecret string
+	Region    string
+	Timezone  string
+}
+
+// CreateProfile creates a named profile with its own config file
+func CreateProfile(name string, settings ProfileSettings) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if name == DefaultProfile {
+		return fmt.Errorf("the default profile always exists; edit %s instead", filepath.Join(cfgDir, "config.yaml"))
+	}
+	if ProfileExists(name) {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+
+	dir := ProfileDir(name)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
+	v := viper.New()
+	if settings.AppID != "" {
+		v.Set("app_id", settings.AppID)
+	}
+	if settings.AppSecret != "" {
+		v.Set("app_secret", settings.AppSecret)
+	}
+	if settings.Region != "" {
+		v.Set("region", settings.Region)
+	}
+	if settings.Timezone != "" {
+		v.Set("defaults.timezone", settings.Timezone)
+	}
+
+	path := filepath.Join(dir, "config.yaml")
+	if err := v.WriteConfigAs(path); err != nil {
+		os.RemoveAll(dir)
+		return fmt.Errorf("failed to write profile config: %w", err)
+	}
+	// The file may contain the app secret
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("failed to restrict profile config permissions: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveProfile deletes a named profile's directory, including its tokens.
+// If it was the saved current profile, the default profile becomes current.
+func RemoveProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if name == DefaultProfile {
+		return fmt.Errorf("the default profile cannot be removed")
+	}
+	if !ProfileExists(name) {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+
+	if err := os.RemoveAll(ProfileDir(name)); err != nil {
+		return fmt.Errorf("failed to remove profile: %w", err)
+	}
+
+	if GetCurrentProfile() == name {
+		return SetCurrentProfile(DefaultProfile)
+	}
+	return nil
+}
+
+// LoadProfile reads a profile's configuration without activating it.
+// Environment overrides for that profile's app credentials are applied.
+func LoadProfile(name string) (*Config, error) {
+	v := newViper(name)
+	if err := readConfig(v); err != nil {
+		return nil, err
+	}
+
+	c := &Config{}
+	if err := v.Unmarshal(c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	c.Region = normalizeRegion(c.Region)
+	return c, nil
+}
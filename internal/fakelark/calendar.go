@@ -0,0 +1,473 @@
+package fakelark
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yjwong/lark-cli/internal/api"
+)
+
+func (s *Server) calendarRoutes(mux *http.ServeMux) {
+	const base = "/open-apis/calendar/v4"
+
+	mux.HandleFunc("POST "+base+"/calendars/primary", s.authed(s.handlePrimaryCalendar))
+	mux.HandleFunc("GET "+base+"/calendars", s.authed(s.handleListCalendars))
+	mux.HandleFunc("GET "+base+"/calendars/{calendar_id}", s.authed(s.handleGetCalendar))
+	mux.HandleFunc("GET "+base+"/calendars/{calendar_id}/events/instance_view", s.authed(s.handleInstanceView))
+	mux.HandleFunc("POST "+base+"/calendars/{calendar_id}/events", s.authed(s.handleCreateEvent))
+	mux.HandleFunc("POST "+base+"/calendars/{calendar_id}/events/search", s.authed(s.handleSearchEvents))
+	mux.HandleFunc("GET "+base+"/calendars/{calendar_id}/events/{event_id}", s.authed(s.handleGetEvent))
+	mux.HandleFunc("PATCH "+base+"/calendars/{calendar_id}/events/{event_id}", s.authed(s.handleUpdateEvent))
+	mux.HandleFunc("DELETE "+base+"/calendars/{calendar_id}/events/{event_id}", s.authed(s.handleDeleteEvent))
+	mux.HandleFunc("POST "+base+"/calendars/{calendar_id}/events/{event_id}/reply", s.authed(s.handleReplyEvent))
+	mux.HandleFunc("GET "+base+"/calendars/{calendar_id}/events/{event_id}/attendees", s.authed(s.handleListAttendees))
+	mux.HandleFunc("POST "+base+"/calendars/{calendar_id}/events/{event_id}/attendees", s.authed(s.handleCreateAttendees))
+	mux.HandleFunc("POST "+base+"/calendars/{calendar_id}/events/{event_id}/attendees/batch_delete", s.authed(s.handleDeleteAttendees))
+	mux.HandleFunc("POST "+base+"/freebusy/list", s.authed(s.handleFreebusy))
+	mux.HandleFunc("POST "+base+"/common_freetime/mget", s.authed(s.handleCommonFreeTime))
+}
+
+// AddEvent stores an event on a calendar and returns its ID
+func (s *Server) AddEvent(calendarID string, e api.Event) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e.EventID == "" {
+		e.EventID = s.newID("fake_event_") + "_0"
+	}
+	if e.Status == "" {
+		e.Status = "confirmed"
+	}
+	s.events[calendarID] = append(s.events[calendarID], &e)
+	return e.EventID
+}
+
+// Events returns a copy of the events stored on a calendar
+func (s *Server) Events(calendarID string) []api.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []api.Event
+	for _, e := range s.events[calendarID] {
+		out = append(out, *e)
+	}
+	return out
+}
+
+// findEvent returns the stored event. Callers must hold s.mu.
+func (s *Server) findEvent(calendarID, eventID string) *api.Event {
+	for _, e := range s.events[calendarID] {
+		if e.EventID == eventID {
+			return e
+		}
+	}
+	return nil
+}
+
+// eventStart returns the start of an event as a time. Callers must hold s.mu.
+func eventStart(e *api.Event) time.Time {
+	return timeInfoToTime(e.StartTime)
+}
+
+func timeInfoToTime(ti *api.TimeInfo) time.Time {
+	if ti == nil {
+		return time.Time{}
+	}
+	if ti.Timestamp != "" {
+		ts, _ := strconv.ParseInt(ti.Timestamp, 10, 64)
+		return time.Unix(ts, 0)
+	}
+	t, _ := time.Parse("2006-01-02", ti.Date)
+	return t
+}
+
+func (s *Server) handlePrimaryCalendar(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	cal := s.calendars[0]
+	userID := s.currentUser.OpenID
+	s.mu.Unlock()
+
+	writeData(w, map[string]interface{}{
+		"calendars": []api.UserCalendar{{Calendar: &cal, UserID: userID}},
+	})
+}
+
+func (s *Server) handleListCalendars(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	cals := append([]api.Calendar(nil), s.calendars...)
+	s.mu.Unlock()
+
+	writeData(w, map[string]interface{}{
+		"has_more":  false,
+		"calendars": cals,
+	})
+}
+
+func (s *Server) handleGetCalendar(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("calendar_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cal := range s.calendars {
+		if cal.CalendarID == id {
+			writeData(w, cal)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, 191001, "calendar not found")
+}
+
+func (s *Server) handleInstanceView(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, _ := strconv.ParseInt(q.Get("start_time"), 10, 64)
+	to, _ := strconv.ParseInt(q.Get("end_time"), 10, 64)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []api.InstanceViewItem
+	for _, e := range s.events[r.PathValue("calendar_id")] {
+		start := eventStart(e).Unix()
+		end := timeInfoToTime(e.EndTime).Unix()
+		if end <= from || start >= to {
+			continue
+		}
+		items = append(items, api.InstanceViewItem{
+			EventID:             e.EventID,
+			Summary:             e.Summary,
+			Description:         e.Description,
+			StartTime:           e.StartTime,
+			EndTime:             e.EndTime,
+			Status:              e.Status,
+			IsException:         e.IsException,
+			OrganizerCalendarID: e.OrganizerCalendarID,
+			Vchat:               e.Vchat,
+			Visibility:          e.Visibility,
+			AttendeeAbility:     e.AttendeeAbility,
+			FreeBusyStatus:      e.FreeBusyStatus,
+			Location:            e.Location,
+			Color:               e.Color,
+			RecurringEventID:    e.RecurringEventID,
+			Attendees:           e.Attendees,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return timeInfoToTime(items[i].StartTime).Before(timeInfoToTime(items[j].StartTime))
+	})
+
+	writeData(w, map[string]interface{}{"items": items})
+}
+
+func (s *Server) handleCreateEvent(w http.ResponseWriter, r *http.Request) {
+	var req api.Event
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.StartTime == nil || req.EndTime == nil {
+		writeError(w, http.StatusBadRequest, 190002, "start_time and end_time are required")
+		return
+	}
+
+	calendarID := r.PathValue("calendar_id")
+	req.OrganizerCalendarID = calendarID
+	req.EventID = ""
+	id := s.AddEvent(calendarID, req)
+
+	s.mu.Lock()
+	e := *s.findEvent(calendarID, id)
+	s.mu.Unlock()
+
+	writeData(w, map[string]interface{}{"event": e})
+}
+
+func (s *Server) handleSearchEvents(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query string `json:"query"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []api.Event
+	query := strings.ToLower(req.Query)
+	for _, e := range s.events[r.PathValue("calendar_id")] {
+		if strings.Contains(strings.ToLower(e.Summary), query) || strings.Contains(strings.ToLower(e.Description), query) {
+			items = append(items, *e)
+		}
+	}
+
+	writeData(w, map[string]interface{}{"items": items, "has_more": false})
+}
+
+func (s *Server) handleGetEvent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.findEvent(r.PathValue("calendar_id"), r.PathValue("event_id"))
+	if e == nil {
+		writeError(w, http.StatusNotFound, 193001, "event not found")
+		return
+	}
+	writeData(w, map[string]interface{}{"event": *e})
+}
+
+func (s *Server) handleUpdateEvent(w http.ResponseWriter, r *http.Request) {
+	var req api.Event
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.findEvent(r.PathValue("calendar_id"), r.PathValue("event_id"))
+	if e == nil {
+		writeError(w, http.StatusNotFound, 193001, "event not found")
+		return
+	}
+
+	if req.Summary != "" {
+		e.Summary = req.Summary
+	}
+	if req.Description != "" {
+		e.Description = req.Description
+	}
+	if req.StartTime != nil {
+		e.StartTime = req.StartTime
+	}
+	if req.EndTime != nil {
+		e.EndTime = req.EndTime
+	}
+	if req.Location != nil {
+		e.Location = req.Location
+	}
+	if req.Color != 0 {
+		e.Color = req.Color
+	}
+	if req.Visibility != "" {
+		e.Visibility = req.Visibility
+	}
+	if req.AttendeeAbility != "" {
+		e.AttendeeAbility = req.AttendeeAbility
+	}
+	if req.Recurrence != "" {
+		e.Recurrence = req.Recurrence
+	}
+	if len(req.Reminders) > 0 {
+		e.Reminders = req.Reminders
+	}
+
+	writeData(w, map[string]interface{}{"event": *e})
+}
+
+func (s *Server) handleDeleteEvent(w http.ResponseWriter, r *http.Request) {
+	calendarID := r.PathValue("calendar_id")
+	eventID := r.PathValue("event_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := s.events[calendarID]
+	for i, e := range events {
+		if e.EventID == eventID {
+			s.events[calendarID] = append(events[:i], events[i+1:]...)
+			writeData(w, map[string]interface{}{})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, 193001, "event not found")
+}
+
+func (s *Server) handleReplyEvent(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RsvpStatus string `json:"rsvp_status"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.findEvent(r.PathValue("calendar_id"), r.PathValue("event_id"))
+	if e == nil {
+		writeError(w, http.StatusNotFound, 193001, "event not found")
+		return
+	}
+	for i := range e.Attendees {
+		if e.Attendees[i].UserID == s.currentUser.OpenID {
+			e.Attendees[i].RsvpStatus = req.RsvpStatus
+		}
+	}
+	writeData(w, map[string]interface{}{})
+}
+
+func (s *Server) handleListAttendees(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.findEvent(r.PathValue("calendar_id"), r.PathValue("event_id"))
+	if e == nil {
+		writeError(w, http.StatusNotFound, 193001, "event not found")
+		return
+	}
+	writeData(w, map[string]interface{}{"items": e.Attendees, "has_more": false})
+}
+
+func (s *Server) handleCreateAttendees(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Attendees []api.Attendee `json:"attendees"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.findEvent(r.PathValue("calendar_id"), r.PathValue("event_id"))
+	if e == nil {
+		writeError(w, http.StatusNotFound, 193001, "event not found")
+		return
+	}
+
+	for _, att := range req.Attendees {
+		att.AttendeeID = s.newID("user_")
+		att.RsvpStatus = "needs_action"
+		if att.UserID == s.currentUser.OpenID {
+			att.DisplayName = s.currentUser.Name
+			att.RsvpStatus = "accept"
+			att.IsOrganizer = true
+		} else if u := s.findUser(att.UserID); u != nil {
+			att.DisplayName = u.Name
+		} else if att.ThirdPartyEmail != "" {
+			att.DisplayName = att.ThirdPartyEmail
+		}
+		e.Attendees = append(e.Attendees, att)
+	}
+
+	writeData(w, map[string]interface{}{"attendees": e.Attendees})
+}
+
+func (s *Server) handleDeleteAttendees(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		AttendeeIDs []string `json:"attendee_ids"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.findEvent(r.PathValue("calendar_id"), r.PathValue("event_id"))
+	if e == nil {
+		writeError(w, http.StatusNotFound, 193001, "event not found")
+		return
+	}
+
+	remove := make(map[string]bool, len(req.AttendeeIDs))
+	for _, id := range req.AttendeeIDs {
+		remove[id] = true
+	}
+	kept := e.Attendees[:0]
+	for _, att := range e.Attendees {
+		if !remove[att.AttendeeID] {
+			kept = append(kept, att)
+		}
+	}
+	e.Attendees = kept
+
+	writeData(w, map[string]interface{}{})
+}
+
+// busyPeriods returns the busy periods of the current user's primary calendar
+// that intersect [from, to). Callers must hold s.mu.
+func (s *Server) busyPeriods(from, to time.Time) []api.FreebusyPeriod {
+	var periods []api.FreebusyPeriod
+	for _, e := range s.events[PrimaryCalendarID] {
+		start := eventStart(e)
+		end := timeInfoToTime(e.EndTime)
+		if !end.After(from) || !start.Before(to) || e.FreeBusyStatus == "free" {
+			continue
+		}
+		periods = append(periods, api.FreebusyPeriod{
+			StartTime: start.Format(time.RFC3339),
+			EndTime:   end.Format(time.RFC3339),
+		})
+	}
+	return periods
+}
+
+func (s *Server) handleFreebusy(w http.ResponseWriter, r *http.Request) {
+	var req api.FreebusyRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	from, _ := time.Parse(time.RFC3339, req.TimeMin)
+	to, _ := time.Parse(time.RFC3339, req.TimeMax)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var periods []api.FreebusyPeriod
+	if req.UserID == s.currentUser.OpenID {
+		periods = s.busyPeriods(from, to)
+	}
+	writeData(w, map[string]interface{}{"freebusy_list": periods})
+}
+
+func (s *Server) handleCommonFreeTime(w http.ResponseWriter, r *http.Request) {
+	var req api.CommonFreeTimeRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	const layout = "2006-01-02 15:04:05"
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	from, _ := time.ParseInLocation(layout, req.StartTime, loc)
+	to, _ := time.ParseInLocation(layout, req.EndTime, loc)
+
+	s.mu.Lock()
+	busy := s.busyPeriods(from, to)
+	s.mu.Unlock()
+
+	sort.Slice(busy, func(i, j int) bool { return busy[i].StartTime < busy[j].StartTime })
+
+	// Free slots are the gaps between the current user's busy periods
+	var items []api.FreeTimeSlot
+	cursor := from
+	addSlot := func(end time.Time) {
+		length := int(end.Sub(cursor).Seconds())
+		if length > 0 && length >= req.MinTimeLength {
+			items = append(items, api.FreeTimeSlot{
+				StartTime: cursor.In(loc).Format(layout),
+				EndTime:   end.In(loc).Format(layout),
+				Length:    length,
+			})
+		}
+	}
+	for _, p := range busy {
+		start, _ := time.Parse(time.RFC3339, p.StartTime)
+		end, _ := time.Parse(time.RFC3339, p.EndTime)
+		if start.After(cursor) {
+			addSlot(start)
+		}
+		if end.After(cursor) {
+			cursor = end
+		}
+	}
+	addSlot(to)
+
+	if req.Limit > 0 && len(items) > req.Limit {
+		items = items[:req.Limit]
+	}
+	writeData(w, map[string]interface{}{"items": items})
+}
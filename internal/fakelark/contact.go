@@ -0,0 +1,128 @@
+package fakelark
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/yjwong/lark-cli/internal/api"
+)
+
+func (s *Server) contactRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /open-apis/contact/v3/users/batch_get_id", s.authed(s.handleBatchGetID))
+	mux.HandleFunc("GET /open-apis/contact/v3/users/find_by_department", s.authed(s.handleFindByDepartment))
+	mux.HandleFunc("GET /open-apis/contact/v3/users/{user_id}", s.authed(s.handleGetUser))
+	mux.HandleFunc("GET /open-apis/search/v1/user", s.authed(s.handleSearchUsers))
+}
+
+// AddUser registers a contact and returns its open_id
+func (s *Server) AddUser(u api.ContactUser) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u.OpenID == "" {
+		u.OpenID = s.newID("ou_fake_")
+	}
+	s.users = append(s.users, u)
+	return u.OpenID
+}
+
+// findUser returns the user with the given open_id, user_id or union_id.
+// Callers must hold s.mu.
+func (s *Server) findUser(id string) *api.ContactUser {
+	if id == "" {
+		return nil
+	}
+	for i := range s.users {
+		u := &s.users[i]
+		if u.OpenID == id || u.UserID == id || u.UnionID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleBatchGetID(w http.ResponseWriter, r *http.Request) {
+	var req api.UserLookupRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Unknown emails and mobiles are returned without a user_id, as Lark does
+	var list []api.UserContactInfo
+	for _, email := range req.Emails {
+		info := api.UserContactInfo{Email: email}
+		for _, u := range s.users {
+			if strings.EqualFold(u.Email, email) {
+				info.UserID = u.OpenID
+			}
+		}
+		list = append(list, info)
+	}
+	for _, mobile := range req.Mobiles {
+		info := api.UserContactInfo{Mobile: mobile}
+		for _, u := range s.users {
+			if u.Mobile == mobile {
+				info.UserID = u.OpenID
+			}
+		}
+		list = append(list, info)
+	}
+
+	writeData(w, map[string]interface{}{"user_list": list})
+}
+
+func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.findUser(r.PathValue("user_id"))
+	if u == nil {
+		writeError(w, http.StatusBadRequest, 41050, "no user authority error")
+		return
+	}
+	writeData(w, map[string]interface{}{"user": *u})
+}
+
+func (s *Server) handleFindByDepartment(w http.ResponseWriter, r *http.Request) {
+	deptID := r.URL.Query().Get("department_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []api.ContactUser
+	for _, u := range s.users {
+		if deptID == "0" && len(u.DepartmentIDs) == 0 {
+			items = append(items, u)
+			continue
+		}
+		for _, d := range u.DepartmentIDs {
+			if d == deptID {
+				items = append(items, u)
+				break
+			}
+		}
+	}
+	writeData(w, map[string]interface{}{"items": items, "has_more": false})
+}
+
+func (s *Server) handleSearchUsers(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var users []api.SearchUserResult
+	for _, u := range s.users {
+		if strings.Contains(strings.ToLower(u.Name), query) || strings.Contains(strings.ToLower(u.EnName), query) {
+			users = append(users, api.SearchUserResult{
+				Name:          u.Name,
+				OpenID:        u.OpenID,
+				UserID:        u.UserID,
+				DepartmentIDs: u.DepartmentIDs,
+			})
+		}
+	}
+	writeData(w, map[string]interface{}{"users": users, "has_more": false})
+}
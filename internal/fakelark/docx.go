@@ -0,0 +1,260 @@
+package fakelark
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/yjwong/lark-cli/internal/api"
+)
+
+// document is a stored docx document and its blocks in creation order
+type document struct {
+	meta   api.Document
+	blocks []*api.DocumentBlock
+}
+
+// Block types used by the fake when rendering markdown
+const (
+	blockTypePage     = 1
+	blockTypeText     = 2
+	blockTypeHeading1 = 3
+	blockTypeBullet   = 12
+	blockTypeOrdered  = 13
+)
+
+func (s *Server) docxRoutes(mux *http.ServeMux) {
+	const base = "/open-apis/docx/v1/documents"
+
+	mux.HandleFunc("POST "+base, s.authed(s.handleCreateDocument))
+	mux.HandleFunc("GET "+base+"/{document_id}", s.authed(s.handleGetDocument))
+	mux.HandleFunc("GET "+base+"/{document_id}/blocks", s.authed(s.handleListBlocks))
+	mux.HandleFunc("POST "+base+"/{document_id}/blocks/{block_id}/children", s.authed(s.handleCreateBlocks))
+	mux.HandleFunc("PATCH "+base+"/{document_id}/blocks/{block_id}", s.authed(s.handleUpdateBlock))
+	mux.HandleFunc("GET /open-apis/docs/v1/content", s.authed(s.handleDocumentContent))
+}
+
+// AddDocument creates a document containing one text block per paragraph and
+// returns its ID
+func (s *Server) AddDocument(title string, paragraphs ...string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc := s.newDocument(title)
+	page := doc.blocks[0]
+	for _, p := range paragraphs {
+		b := &api.DocumentBlock{
+			BlockID:   s.newID("blk_fake_"),
+			ParentID:  page.BlockID,
+			BlockType: blockTypeText,
+			Text:      textBlock(p),
+		}
+		page.Children = append(page.Children, b.BlockID)
+		doc.blocks = append(doc.blocks, b)
+	}
+	return doc.meta.DocumentID
+}
+
+// newDocument stores an empty document with its page block. Callers must hold s.mu.
+func (s *Server) newDocument(title string) *document {
+	id := s.newID("doxfake")
+	doc := &document{
+		meta: api.Document{DocumentID: id, RevisionID: 1, Title: title},
+		blocks: []*api.DocumentBlock{{
+			BlockID:   id,
+			BlockType: blockTypePage,
+			Page:      textBlock(title),
+		}},
+	}
+	s.documents[id] = doc
+	return doc
+}
+
+func textBlock(content string) *api.TextBlock {
+	return &api.TextBlock{Elements: []api.TextElement{{TextRun: &api.TextRun{Content: content}}}}
+}
+
+// blockText returns the plain text of a block's first text-bearing field
+func blockText(b *api.DocumentBlock) string {
+	for _, tb := range []*api.TextBlock{b.Page, b.Text, b.Heading1, b.Heading2, b.Heading3, b.Bullet, b.Ordered, b.Code, b.Quote, b.TodoBlock} {
+		if tb == nil {
+			continue
+		}
+		var sb strings.Builder
+		for _, el := range tb.Elements {
+			if el.TextRun != nil {
+				sb.WriteString(el.TextRun.Content)
+			}
+		}
+		return sb.String()
+	}
+	return ""
+}
+
+func (s *Server) handleCreateDocument(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateDocumentRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	doc := s.newDocument(req.Title)
+	meta := doc.meta
+	s.mu.Unlock()
+
+	writeData(w, map[string]interface{}{"document": meta})
+}
+
+// lookupDocument writes a not-found error if the document does not exist.
+// Callers must hold s.mu.
+func (s *Server) lookupDocument(w http.ResponseWriter, r *http.Request) *document {
+	doc, ok := s.documents[r.PathValue("document_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, 1770002, "document not found")
+		return nil
+	}
+	return doc
+}
+
+func (s *Server) handleGetDocument(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if doc := s.lookupDocument(w, r); doc != nil {
+		writeData(w, map[string]interface{}{"document": doc.meta})
+	}
+}
+
+func (s *Server) handleListBlocks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc := s.lookupDocument(w, r)
+	if doc == nil {
+		return
+	}
+	items := make([]api.DocumentBlock, 0, len(doc.blocks))
+	for _, b := range doc.blocks {
+		items = append(items, *b)
+	}
+	writeData(w, map[string]interface{}{"items": items, "has_more": false})
+}
+
+func (s *Server) handleCreateBlocks(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateBlockChildrenRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc := s.lookupDocument(w, r)
+	if doc == nil {
+		return
+	}
+
+	var parent *api.DocumentBlock
+	for _, b := range doc.blocks {
+		if b.BlockID == r.PathValue("block_id") {
+			parent = b
+		}
+	}
+	if parent == nil {
+		writeError(w, http.StatusNotFound, 1770002, "block not found")
+		return
+	}
+
+	var created []api.DocumentBlock
+	var ids []string
+	for _, child := range req.Children {
+		b := child
+		b.BlockID = s.newID("blk_fake_")
+		b.ParentID = parent.BlockID
+		doc.blocks = append(doc.blocks, &b)
+		created = append(created, b)
+		ids = append(ids, b.BlockID)
+	}
+
+	if req.Index < 0 || req.Index >= len(parent.Children) {
+		parent.Children = append(parent.Children, ids...)
+	} else {
+		parent.Children = append(parent.Children[:req.Index], append(ids, parent.Children[req.Index:]...)...)
+	}
+	doc.meta.RevisionID++
+
+	writeData(w, map[string]interface{}{
+		"children":             created,
+		"document_revision_id": doc.meta.RevisionID,
+	})
+}
+
+func (s *Server) handleUpdateBlock(w http.ResponseWriter, r *http.Request) {
+	var req api.UpdateBlockRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc := s.lookupDocument(w, r)
+	if doc == nil {
+		return
+	}
+
+	for _, b := range doc.blocks {
+		if b.BlockID != r.PathValue("block_id") {
+			continue
+		}
+		if req.UpdateTextElements != nil {
+			tb := &api.TextBlock{Elements: req.UpdateTextElements.Elements}
+			switch {
+			case b.Heading1 != nil:
+				b.Heading1 = tb
+			case b.Bullet != nil:
+				b.Bullet = tb
+			case b.Ordered != nil:
+				b.Ordered = tb
+			default:
+				b.Text = tb
+			}
+		}
+		doc.meta.RevisionID++
+		writeData(w, map[string]interface{}{
+			"block":                *b,
+			"document_revision_id": doc.meta.RevisionID,
+		})
+		return
+	}
+	writeError(w, http.StatusNotFound, 1770002, "block not found")
+}
+
+func (s *Server) handleDocumentContent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, ok := s.documents[r.URL.Query().Get("doc_token")]
+	if !ok {
+		writeError(w, http.StatusNotFound, 1770002, "document not found")
+		return
+	}
+
+	// Render a minimal markdown approximation of the document
+	var sb strings.Builder
+	sb.WriteString("# " + doc.meta.Title + "\n")
+	for _, b := range doc.blocks[1:] {
+		text := blockText(b)
+		switch b.BlockType {
+		case blockTypeHeading1:
+			sb.WriteString("\n# " + text + "\n")
+		case blockTypeBullet:
+			sb.WriteString("- " + text + "\n")
+		case blockTypeOrdered:
+			sb.WriteString("1. " + text + "\n")
+		default:
+			sb.WriteString("\n" + text + "\n")
+		}
+	}
+
+	writeData(w, map[string]interface{}{"content": sb.String()})
+}
@@ -0,0 +1,258 @@
+package fakelark
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yjwong/lark-cli/internal/api"
+)
+
+func (s *Server) imRoutes(mux *http.ServeMux) {
+	const base = "/open-apis/im/v1"
+
+	mux.HandleFunc("POST "+base+"/messages", s.authed(s.handleSendMessage))
+	mux.HandleFunc("GET "+base+"/messages", s.authed(s.handleListMessages))
+	mux.HandleFunc("POST "+base+"/messages/{message_id}/reply", s.authed(s.handleReplyMessage))
+	mux.HandleFunc("DELETE "+base+"/messages/{message_id}", s.authed(s.handleRecallMessage))
+	mux.HandleFunc("GET "+base+"/messages/{message_id}/reactions", s.authed(s.handleListReactions))
+	mux.HandleFunc("POST "+base+"/messages/{message_id}/reactions", s.authed(s.handleAddReaction))
+	mux.HandleFunc("DELETE "+base+"/messages/{message_id}/reactions/{reaction_id}", s.authed(s.handleDeleteReaction))
+	mux.HandleFunc("POST "+base+"/images", s.authed(s.handleUploadImage))
+	mux.HandleFunc("GET "+base+"/chats/search", s.authed(s.handleSearchChats))
+}
+
+// AddChat registers a chat and returns its ID
+func (s *Server) AddChat(chat api.Chat) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if chat.ChatID == "" {
+		chat.ChatID = s.newID("oc_fake_")
+	}
+	s.chats = append(s.chats, chat)
+	return chat.ChatID
+}
+
+// Messages returns a copy of every message sent to the server
+func (s *Server) Messages() []api.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]api.Message, 0, len(s.messages))
+	for _, m := range s.messages {
+		out = append(out, *m)
+	}
+	return out
+}
+
+// findMessage returns the stored message. Callers must hold s.mu.
+func (s *Server) findMessage(id string) *api.Message {
+	for _, m := range s.messages {
+		if m.MessageID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+// storeMessage records a new message sent by the app. Callers must hold s.mu.
+func (s *Server) storeMessage(chatID, msgType, content string) *api.Message {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	m := &api.Message{
+		MessageID:  s.newID("om_fake_"),
+		MsgType:    msgType,
+		CreateTime: now,
+		UpdateTime: now,
+		ChatID:     chatID,
+		Sender:     &api.MessageSender{ID: "cli_fake_app", IDType: "app_id", SenderType: "app"},
+		Body:       &api.MessageBody{Content: content},
+	}
+	s.messages = append(s.messages, m)
+	return m
+}
+
+func (s *Server) handleSendMessage(w http.ResponseWriter, r *http.Request) {
+	var req api.SendMessageRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.ReceiveID == "" || req.MsgType == "" || req.Content == "" {
+		writeError(w, http.StatusBadRequest, 230001, "receive_id, msg_type and content are required")
+		return
+	}
+
+	// Direct messages are stored under a synthetic p2p chat per receiver
+	chatID := req.ReceiveID
+	if r.URL.Query().Get("receive_id_type") != "chat_id" {
+		chatID = "oc_p2p_" + req.ReceiveID
+	}
+
+	s.mu.Lock()
+	m := *s.storeMessage(chatID, req.MsgType, req.Content)
+	s.mu.Unlock()
+
+	writeData(w, m)
+}
+
+func (s *Server) handleListMessages(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	containerID := q.Get("container_id")
+	start, _ := strconv.ParseInt(q.Get("start_time"), 10, 64)
+	end, _ := strconv.ParseInt(q.Get("end_time"), 10, 64)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []api.Message
+	for _, m := range s.messages {
+		if m.ChatID != containerID && m.ThreadID != containerID {
+			continue
+		}
+		created, _ := strconv.ParseInt(m.CreateTime, 10, 64)
+		if (start > 0 && created/1000 < start) || (end > 0 && created/1000 > end) {
+			continue
+		}
+		items = append(items, *m)
+	}
+	if q.Get("sort_type") == "ByCreateTimeDesc" {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	writeData(w, map[string]interface{}{"items": items, "has_more": false})
+}
+
+func (s *Server) handleReplyMessage(w http.ResponseWriter, r *http.Request) {
+	var req api.ReplyMessageRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parent := s.findMessage(r.PathValue("message_id"))
+	if parent == nil {
+		writeError(w, http.StatusBadRequest, 230011, "message not found")
+		return
+	}
+
+	m := s.storeMessage(parent.ChatID, req.MsgType, req.Content)
+	m.ParentID = parent.MessageID
+	m.RootID = parent.MessageID
+	if parent.RootID != "" {
+		m.RootID = parent.RootID
+	}
+	if req.ReplyInThread {
+		if parent.ThreadID == "" {
+			parent.ThreadID = s.newID("omt_fake_")
+		}
+		m.ThreadID = parent.ThreadID
+	}
+
+	writeData(w, *m)
+}
+
+func (s *Server) handleRecallMessage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.findMessage(r.PathValue("message_id"))
+	if m == nil {
+		writeError(w, http.StatusBadRequest, 230011, "message not found")
+		return
+	}
+	m.Deleted = true
+	writeData(w, map[string]interface{}{})
+}
+
+func (s *Server) handleListReactions(w http.ResponseWriter, r *http.Request) {
+	reactionType := r.URL.Query().Get("reaction_type")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []api.MessageReaction
+	for _, reaction := range s.reactions[r.PathValue("message_id")] {
+		if reactionType == "" || reaction.ReactionType.EmojiType == reactionType {
+			items = append(items, reaction)
+		}
+	}
+	writeData(w, map[string]interface{}{"items": items, "has_more": false})
+}
+
+func (s *Server) handleAddReaction(w http.ResponseWriter, r *http.Request) {
+	var req api.AddMessageReactionRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("message_id")
+	if s.findMessage(id) == nil {
+		writeError(w, http.StatusBadRequest, 230011, "message not found")
+		return
+	}
+
+	reaction := api.MessageReaction{
+		ReactionID:   s.newID("reaction_"),
+		ReactionType: &api.ReactionType{EmojiType: req.ReactionType.EmojiType},
+		Operator:     &api.ReactionOperator{OperatorID: "cli_fake_app", OperatorType: "app"},
+		ActionTime:   strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}
+	s.reactions[id] = append(s.reactions[id], reaction)
+	writeData(w, reaction)
+}
+
+func (s *Server) handleDeleteReaction(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("message_id")
+	reactionID := r.PathValue("reaction_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reactions := s.reactions[id]
+	for i, reaction := range reactions {
+		if reaction.ReactionID == reactionID {
+			s.reactions[id] = append(reactions[:i], reactions[i+1:]...)
+			writeData(w, reaction)
+			return
+		}
+	}
+	writeError(w, http.StatusBadRequest, 231003, "reaction not found")
+}
+
+func (s *Server) handleUploadImage(w http.ResponseWriter, r *http.Request) {
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, 234001, "missing image: "+err.Error())
+		return
+	}
+	defer file.Close()
+	io.Copy(io.Discard, file)
+
+	s.mu.Lock()
+	key := s.newID("img_v3_fake_")
+	s.mu.Unlock()
+
+	writeData(w, map[string]interface{}{"image_key": key})
+}
+
+func (s *Server) handleSearchChats(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []api.Chat
+	for _, chat := range s.chats {
+		if query == "" || strings.Contains(strings.ToLower(chat.Name), query) {
+			items = append(items, chat)
+		}
+	}
+	writeData(w, map[string]interface{}{"items": items, "has_more": false})
+}
@@ -0,0 +1,289 @@
+// Package fakelark provides an in-memory fake of the Lark Open Platform API.
+//
+// It implements the subset of calendar, IM, docx, contact and auth endpoints
+// used by the CLI, so commands can be exercised end-to-end without network
+// access. Point the CLI at it with LARK_OPEN_BASE_URL and
+// LARK_ACCOUNTS_BASE_URL (see Server.Env), or inject Server.Client().Transport
+// with auth.SetTransport.
+package fakelark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/yjwong/lark-cli/internal/api"
+	"github.com/yjwong/lark-cli/internal/scopes"
+)
+
+const (
+	// AccessToken is the user access token issued by the fake server
+	AccessToken = "fake-user-access-token"
+	// RefreshToken is the refresh token issued by the fake server
+	RefreshToken = "fake-user-refresh-token"
+	// TenantAccessToken is the tenant access token issued by the fake server
+	TenantAccessToken = "fake-tenant-access-token"
+	// PrimaryCalendarID is the ID of the current user's primary calendar
+	PrimaryCalendarID = "fake_primary_calendar"
+)
+
+// Request is a request received by the fake server
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// injectedError is a failure the server returns instead of handling a request
+type injectedError struct {
+	method     string
+	pathPrefix string
+	httpStatus int
+	code       int
+	remaining  int
+}
+
+// Server is an in-memory fake Lark API server
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int
+	currentUser api.ContactUser
+	users       []api.ContactUser
+	calendars   []api.Calendar
+	events      map[string][]*api.Event // calendar ID -> events
+	chats       []api.Chat
+	messages    []*api.Message
+	reactions   map[string][]api.MessageReaction // message ID -> reactions
+	documents   map[string]*document
+	requests    []Request
+	errors      []*injectedError
+}
+
+// New starts a fake server seeded with a current user and a primary calendar.
+// The caller must call Close when done.
+func New() *Server {
+	s := &Server{
+		currentUser: api.ContactUser{
+			OpenID: "ou_fake_me",
+			UserID: "fake_me",
+			Name:   "Fake User",
+			Email:  "me@example.com",
+		},
+		events:    make(map[string][]*api.Event),
+		reactions: make(map[string][]api.MessageReaction),
+		documents: make(map[string]*document),
+	}
+	s.users = append(s.users, s.currentUser)
+	s.calendars = append(s.calendars, api.Calendar{
+		CalendarID: PrimaryCalendarID,
+		Summary:    "Fake User",
+		Type:       "primary",
+		Role:       "owner",
+	})
+
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Env returns the environment variables that point the CLI at this server
+func (s *Server) Env() map[string]string {
+	return map[string]string{
+		"LARK_OPEN_BASE_URL":     s.URL,
+		"LARK_ACCOUNTS_BASE_URL": s.URL,
+		"LARK_APP_ID":            "cli_fake_app",
+		"LARK_APP_SECRET":        "fake_app_secret",
+	}
+}
+
+// CurrentUser returns the user that the issued access token belongs to
+func (s *Server) CurrentUser() api.ContactUser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.currentUser
+}
+
+// Requests returns every request received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// InjectError makes the next `times` requests whose method matches and whose
+// path (after /open-apis) starts with pathPrefix fail with the given HTTP
+// status and Lark code. An empty method matches any method.
+func (s *Server) InjectError(method, pathPrefix string, httpStatus, code, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = append(s.errors, &injectedError{
+		method:     method,
+		pathPrefix: pathPrefix,
+		httpStatus: httpStatus,
+		code:       code,
+		remaining:  times,
+	})
+}
+
+// routes builds the request router
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	// Auth
+	mux.HandleFunc("GET /open-apis/authen/v1/authorize", s.handleAuthorize)
+	mux.HandleFunc("POST /open-apis/authen/v2/oauth/token", s.handleOAuthToken)
+	mux.HandleFunc("POST /open-apis/auth/v3/tenant_access_token/internal", s.handleTenantToken)
+	mux.HandleFunc("GET /open-apis/authen/v1/user_info", s.authed(s.handleUserInfo))
+
+	s.calendarRoutes(mux)
+	s.imRoutes(mux)
+	s.docxRoutes(mux)
+	s.contactRoutes(mux)
+
+	return s.record(mux)
+}
+
+// record logs each request and applies injected errors
+func (s *Server) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+		path := strings.TrimPrefix(r.URL.Path, "/open-apis")
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   path,
+			Query:  r.URL.RawQuery,
+			Body:   string(body),
+		})
+		var injected *injectedError
+		for _, e := range s.errors {
+			if e.remaining > 0 && (e.method == "" || e.method == r.Method) && strings.HasPrefix(path, e.pathPrefix) {
+				e.remaining--
+				injected = e
+				break
+			}
+		}
+		s.mu.Unlock()
+
+		if injected != nil {
+			writeError(w, injected.httpStatus, injected.code, "injected error")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authed rejects requests without a bearer token
+func (s *Server) authed(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token != AccessToken && token != TenantAccessToken {
+			writeError(w, http.StatusUnauthorized, 99991663, "invalid access token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	// Immediately redirect back with a code, as if the user approved
+	q := r.URL.Query()
+	redirect := q.Get("redirect_uri") + "?code=fake-auth-code&state=" + q.Get("state")
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+func (s *Server) handleOAuthToken(w http.ResponseWriter, r *http.Request) {
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, 20001, "invalid request body")
+		return
+	}
+
+	switch req["grant_type"] {
+	case "authorization_code", "refresh_token":
+	default:
+		writeError(w, http.StatusBadRequest, 20001, "unsupported grant_type")
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"code":                     0,
+		"access_token":             AccessToken,
+		"refresh_token":            RefreshToken,
+		"expires_in":               7200,
+		"refresh_token_expires_in": 2592000,
+		"token_type":               "Bearer",
+		"scope":                    scopes.GetAllScopeString(),
+	})
+}
+
+func (s *Server) handleTenantToken(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"code":                0,
+		"msg":                 "ok",
+		"tenant_access_token": TenantAccessToken,
+		"expire":              7200,
+	})
+}
+
+func (s *Server) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	u := s.CurrentUser()
+	writeData(w, api.UserInfo{
+		Name:      u.Name,
+		OpenID:    u.OpenID,
+		UnionID:   u.UnionID,
+		Email:     u.Email,
+		TenantKey: "fake_tenant",
+	})
+}
+
+// newID returns a unique ID with the given prefix. Callers must hold s.mu.
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s%06d", prefix, s.nextID)
+}
+
+// writeJSON writes v as a 200 JSON response
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeData writes a successful Lark envelope with the given data
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, map[string]interface{}{
+		"code": 0,
+		"msg":  "success",
+		"data": data,
+	})
+}
+
+// writeError writes a failed Lark envelope
+func writeError(w http.ResponseWriter, httpStatus, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Tt-Logid", "fake-log-id")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code": code,
+		"msg":  msg,
+	})
+}
+
+// decodeBody decodes a JSON request body, writing an error response on failure
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, 99992402, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
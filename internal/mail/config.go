@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"github.com/yjwong/lark-cli/internal/config"
+	"github.com/yjwong/lark-cli/internal/secrets"
 )
 
 // Credentials holds IMAP connection settings
@@ -20,18 +21,18 @@ type Credentials struct {
 
 // CredentialsFilePath returns the path to the mail credentials file
 func CredentialsFilePath() string {
-	return filepath.Join(config.GetConfigDir(), "mail.json")
+	return filepath.Join(config.GetProfileDir(), "mail.json")
 }
 
 // CacheFilePath returns the path to the mail cache database
 func CacheFilePath() string {
-	return filepath.Join(config.GetConfigDir(), "mail_cache.db")
+	return filepath.Join(config.GetProfileDir(), "mail_cache.db")
 }
 
 // LoadCredentials reads IMAP credentials from disk
 func LoadCredentials() (*Credentials, error) {
 	path := CredentialsFilePath()
-	data, err := os.ReadFile(path)
+	data, err := secrets.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("mail not configured; run 'lark mail setup' first")
@@ -55,7 +56,7 @@ func SaveCredentials(creds *Credentials) error {
 	}
 
 	path := CredentialsFilePath()
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	if err := secrets.WriteFile(path, data); err != nil {
 		return fmt.Errorf("failed to write credentials: %w", err)
 	}
 
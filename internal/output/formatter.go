@@ -2,10 +2,18 @@ package output
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 )
 
+// DetailedError is implemented by errors that carry structured fields
+// (such as Lark's numeric error code) that scripts can branch on
+type DetailedError interface {
+	error
+	Details() interface{}
+}
+
 // JSON outputs data as JSON to stdout
 func JSON(v interface{}) {
 	enc := json.NewEncoder(os.Stdout)
@@ -22,9 +30,21 @@ func Error(code, message string) {
 	})
 }
 
-// ErrorFromErr outputs an error from a Go error
+// ErrorFromErr outputs an error from a Go error.
+// If the error carries structured details, they are included under "details".
 func ErrorFromErr(code string, err error) {
-	Error(code, err.Error())
+	var detailed DetailedError
+	if !errors.As(err, &detailed) {
+		Error(code, err.Error())
+		return
+	}
+
+	JSON(map[string]interface{}{
+		"error":   true,
+		"code":    code,
+		"message": err.Error(),
+		"details": detailed.Details(),
+	})
 }
 
 // Success outputs a success message
@@ -37,12 +57,13 @@ func Success(message string) {
 
 // Fatal outputs an error and exits with code 1
 func Fatal(code string, err error) {
-	Error(code, err.Error())
+	ErrorFromErr(code, err)
 	os.Exit(1)
 }
 
-// Fatalf outputs a formatted error and exits
+// Fatalf outputs a formatted error and exits.
+// Wrapped errors (%w) keep their structured details.
 func Fatalf(code, format string, args ...interface{}) {
-	Error(code, fmt.Sprintf(format, args...))
+	ErrorFromErr(code, fmt.Errorf(format, args...))
 	os.Exit(1)
 }
@@ -0,0 +1,358 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"unicode/utf8"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Formats lists the supported values of the --output flag
+var Formats = []string{"json", "ndjson", "yaml", "table", "csv", "tsv"}
+
+// maxTableCellWidth truncates long cells in table output
+const maxTableCellWidth = 60
+
+// Options controls how Print renders command results
+type Options struct {
+	// Format is one of Formats; empty means json
+	Format string
+	// Template is a Go text/template executed for each list item (or the
+	// whole result if it is not a list) instead of Format
+	Template string
+	// Columns selects and orders table/csv/tsv columns. Dotted paths such as
+	// organizer.name reach into nested objects.
+	Columns []string
+}
+
+var (
+	opts Options
+	tmpl *template.Template
+)
+
+// Configure validates and applies global output options
+func Configure(o Options) error {
+	if o.Format == "" {
+		o.Format = "json"
+	}
+	o.Format = strings.ToLower(o.Format)
+
+	valid := false
+	for _, f := range Formats {
+		if o.Format == f {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid output format %q (valid: %s)", o.Format, strings.Join(Formats, ", "))
+	}
+
+	if o.Template != "" {
+		t, err := template.New("output").Funcs(templateFuncs).Option("missingkey=zero").Parse(o.Template)
+		if err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+		tmpl = t
+	}
+
+	opts = o
+	return nil
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(sep string, v interface{}) string {
+		items, ok := v.([]interface{})
+		if !ok {
+			return cellString(v, false)
+		}
+		parts := make([]string, len(items))
+		for i, item := range items {
+			parts[i] = cellString(item, false)
+		}
+		return strings.Join(parts, sep)
+	},
+}
+
+// Print writes a command result to stdout in the configured format
+func Print(v interface{}) {
+	if err := render(os.Stdout, v); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to render output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// render writes v to w according to the global options
+func render(w io.Writer, v interface{}) error {
+	// Plain JSON needs no intermediate representation
+	if opts.Format == "json" && tmpl == nil {
+		return writeJSON(w, v)
+	}
+
+	data, err := normalize(v)
+	if err != nil {
+		return err
+	}
+
+	if tmpl != nil {
+		return renderTemplate(w, data)
+	}
+
+	switch opts.Format {
+	case "ndjson":
+		return renderNDJSON(w, data)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(data); err != nil {
+			return err
+		}
+		return enc.Close()
+	case "table":
+		return renderTable(w, data)
+	case "csv":
+		return renderDelimited(w, data, ',')
+	case "tsv":
+		return renderDelimited(w, data, '\t')
+	default:
+		return writeJSON(w, data)
+	}
+}
+
+// listItems returns the items of a list result: the value itself if it is an
+// array, or the first array-of-objects field of an object (such as "events"
+// in {"events": [...], "count": 2}). ok is false for non-list results.
+func listItems(data interface{}) (items []interface{}, ok bool) {
+	switch t := data.(type) {
+	case []interface{}:
+		return t, true
+	case *object:
+		var firstArray []interface{}
+		found := false
+		for _, k := range t.keys {
+			arr, isArr := t.values[k].([]interface{})
+			if !isArr {
+				continue
+			}
+			if len(arr) > 0 {
+				if _, isObj := arr[0].(*object); isObj {
+					return arr, true
+				}
+			}
+			if !found {
+				firstArray, found = arr, true
+			}
+		}
+		return firstArray, found
+	}
+	return nil, false
+}
+
+func renderTemplate(w io.Writer, data interface{}) error {
+	items, ok := listItems(data)
+	if !ok {
+		items = []interface{}{data}
+	}
+	for _, item := range items {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, plain(item)); err != nil {
+			return fmt.Errorf("template: %w", err)
+		}
+		out := strings.ReplaceAll(buf.String(), "<no value>", "")
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		if _, err := io.WriteString(w, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderNDJSON(w io.Writer, data interface{}) error {
+	items, ok := listItems(data)
+	if !ok {
+		items = []interface{}{data}
+	}
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableColumns returns the requested columns, or by default every scalar
+// (or list of scalars) field in order of first appearance across rows
+func tableColumns(rows []interface{}) []string {
+	if len(opts.Columns) > 0 {
+		return opts.Columns
+	}
+
+	var cols []string
+	seen := map[string]bool{}
+	for _, row := range rows {
+		obj, ok := row.(*object)
+		if !ok {
+			continue
+		}
+		for _, k := range obj.keys {
+			if seen[k] || !isSimple(obj.values[k]) {
+				continue
+			}
+			seen[k] = true
+			cols = append(cols, k)
+		}
+	}
+	return cols
+}
+
+// isSimple reports whether a value reads well in a single table cell
+func isSimple(v interface{}) bool {
+	switch t := v.(type) {
+	case *object:
+		return false
+	case []interface{}:
+		for _, e := range t {
+			if !isSimple(e) {
+				return false
+			}
+			if _, nested := e.([]interface{}); nested {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// lookupPath resolves a dotted path such as organizer.name
+func lookupPath(v interface{}, path string) interface{} {
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := v.(*object)
+		if !ok {
+			return nil
+		}
+		v, _ = obj.get(part)
+	}
+	return v
+}
+
+// cellString formats a value for a table or CSV cell. Lists of scalars are
+// comma-separated; nested objects are written as compact JSON.
+func cellString(v interface{}, truncate bool) string {
+	var s string
+	switch t := v.(type) {
+	case nil:
+		s = ""
+	case string:
+		s = t
+	case json.Number:
+		s = t.String()
+	case bool:
+		s = fmt.Sprintf("%t", t)
+	case []interface{}:
+		if isSimple(t) {
+			parts := make([]string, len(t))
+			for i, e := range t {
+				parts[i] = cellString(e, false)
+			}
+			s = strings.Join(parts, ", ")
+		} else {
+			data, _ := json.Marshal(t)
+			s = string(data)
+		}
+	default:
+		data, _ := json.Marshal(t)
+		s = string(data)
+	}
+
+	if truncate {
+		s = strings.Join(strings.Fields(s), " ")
+		if utf8.RuneCountInString(s) > maxTableCellWidth {
+			s = string([]rune(s)[:maxTableCellWidth-1]) + "…"
+		}
+	}
+	return s
+}
+
+// tableRows returns a header and rows for table, csv and tsv output. Non-list
+// results become FIELD/VALUE rows.
+func tableRows(data interface{}, truncate bool) ([]string, [][]string) {
+	items, ok := listItems(data)
+	if !ok {
+		obj, isObj := data.(*object)
+		if !isObj {
+			return []string{"value"}, [][]string{{cellString(data, truncate)}}
+		}
+		keys := opts.Columns
+		if len(keys) == 0 {
+			keys = obj.keys
+		}
+		var rows [][]string
+		for _, k := range keys {
+			rows = append(rows, []string{k, cellString(lookupPath(obj, k), truncate)})
+		}
+		return []string{"field", "value"}, rows
+	}
+
+	cols := tableColumns(items)
+	var rows [][]string
+	for _, item := range items {
+		row := make([]string, len(cols))
+		for i, c := range cols {
+			if _, isObj := item.(*object); isObj {
+				row[i] = cellString(lookupPath(item, c), truncate)
+			} else if i == 0 {
+				row[i] = cellString(item, truncate)
+			}
+		}
+		rows = append(rows, row)
+	}
+	if len(cols) == 0 {
+		cols = []string{"value"}
+	}
+	return cols, rows
+}
+
+func renderTable(w io.Writer, data interface{}) error {
+	header, rows := tableRows(data, true)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	upper := make([]string, len(header))
+	for i, h := range header {
+		upper[i] = strings.ToUpper(h)
+	}
+	fmt.Fprintln(tw, strings.Join(upper, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func renderDelimited(w io.Writer, data interface{}, comma rune) error {
+	header, rows := tableRows(data, false)
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
@@ -0,0 +1,275 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/yjwong/lark-cli/internal/config"
+)
+
+const (
+	// envelopeFormat marks a file written by the encrypted backend
+	envelopeFormat = "lark-secret/v1"
+
+	kdfPBKDF2 = "pbkdf2-sha256"
+	kdfHKDF   = "hkdf-sha256"
+
+	// pbkdf2Iterations follows the OWASP recommendation for PBKDF2-SHA256
+	pbkdf2Iterations = 600000
+
+	keySize  = 32
+	saltSize = 16
+
+	// hkdfInfo binds derived keys to this use
+	hkdfInfo = "lark-cli secret file"
+)
+
+// envelope is the on-disk format of an encrypted secret file
+type envelope struct {
+	Format     string `json:"format"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations,omitempty"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// IsEncrypted reports whether data was written by the encrypted backend
+func IsEncrypted(data []byte) bool {
+	if !bytes.Contains(data, []byte(envelopeFormat)) {
+		return false
+	}
+	var env envelope
+	return json.Unmarshal(data, &env) == nil && env.Format == envelopeFormat
+}
+
+// envelopeKDF returns the key derivation an encrypted file was sealed with
+func envelopeKDF(data []byte) string {
+	var env envelope
+	if json.Unmarshal(data, &env) != nil {
+		return ""
+	}
+	return env.KDF
+}
+
+// keyMaterial is the secret that encryption keys are derived from
+type keyMaterial struct {
+	secret []byte
+	kdf    string
+}
+
+// derivedKeys caches derived keys by KDF, salt and secret, since PBKDF2 is
+// deliberately slow and one command may read several files
+var (
+	derivedKeysMu sync.Mutex
+	derivedKeys   = map[string][]byte{}
+)
+
+// derive returns the AES key for the given salt and iteration count
+func (m keyMaterial) derive(iterations int, salt []byte) ([]byte, error) {
+	sum := sha256.Sum256(m.secret)
+	cacheKey := m.kdf + ":" + hex.EncodeToString(salt) + ":" + hex.EncodeToString(sum[:])
+
+	derivedKeysMu.Lock()
+	defer derivedKeysMu.Unlock()
+	if key, ok := derivedKeys[cacheKey]; ok {
+		return key, nil
+	}
+
+	var key []byte
+	var err error
+	switch m.kdf {
+	case kdfPBKDF2:
+		key, err = pbkdf2.Key(sha256.New, string(m.secret), salt, iterations, keySize)
+	case kdfHKDF:
+		key, err = hkdf.Key(sha256.New, m.secret, salt, hkdfInfo, keySize)
+	default:
+		err = fmt.Errorf("unsupported key derivation %q", m.kdf)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKeys[cacheKey] = key
+	return key, nil
+}
+
+// loadKeyMaterial returns the key material used for new files: the
+// passphrase from LARK_SECRET_PASSPHRASE, or else the key file. With create
+// set, a missing key file is generated.
+func loadKeyMaterial(create bool) (keyMaterial, error) {
+	if pass := os.Getenv("LARK_SECRET_PASSPHRASE"); pass != "" {
+		return keyMaterial{secret: []byte(pass), kdf: kdfPBKDF2}, nil
+	}
+	return loadKeyFile(create)
+}
+
+// loadKeyFile reads the key file, generating it first if create is set
+func loadKeyFile(create bool) (keyMaterial, error) {
+	path := config.GetSecretsKeyFile()
+	data, err := os.ReadFile(path)
+	if err == nil {
+		key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(key) < keySize {
+			return keyMaterial{}, fmt.Errorf("invalid key file %s: expected at least %d hex-encoded bytes", path, keySize)
+		}
+		return keyMaterial{secret: key, kdf: kdfHKDF}, nil
+	}
+	if !os.IsNotExist(err) {
+		return keyMaterial{}, fmt.Errorf("failed to read key file: %w", err)
+	}
+	if !create {
+		return keyMaterial{}, fmt.Errorf("key file %s not found; set LARK_SECRET_PASSPHRASE or restore the key file", path)
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return keyMaterial{}, fmt.Errorf("failed to generate key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return keyMaterial{}, fmt.Errorf("failed to create key file directory: %w", err)
+	}
+	// O_EXCL so concurrent first runs cannot overwrite each other's key
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return loadKeyFile(false)
+		}
+		return keyMaterial{}, fmt.Errorf("failed to create key file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(hex.EncodeToString(key) + "\n"); err != nil {
+		return keyMaterial{}, fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	return keyMaterial{secret: key, kdf: kdfHKDF}, nil
+}
+
+// openMaterials returns the key material that may have sealed a file using
+// the given KDF. LARK_SECRET_OLD_PASSPHRASE allows reading files sealed with
+// a previous passphrase while migrating to a new one.
+func openMaterials(kdf string) ([]keyMaterial, error) {
+	switch kdf {
+	case kdfPBKDF2:
+		var materials []keyMaterial
+		for _, name := range []string{"LARK_SECRET_PASSPHRASE", "LARK_SECRET_OLD_PASSPHRASE"} {
+			if pass := os.Getenv(name); pass != "" {
+				materials = append(materials, keyMaterial{secret: []byte(pass), kdf: kdfPBKDF2})
+			}
+		}
+		if len(materials) == 0 {
+			return nil, fmt.Errorf("file is encrypted with a passphrase; set LARK_SECRET_PASSPHRASE")
+		}
+		return materials, nil
+	case kdfHKDF:
+		material, err := loadKeyFile(false)
+		if err != nil {
+			return nil, err
+		}
+		return []keyMaterial{material}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key derivation %q", kdf)
+	}
+}
+
+func kdfSourceName(kdf string) string {
+	if kdf == kdfPBKDF2 {
+		return "passphrase"
+	}
+	return "key file"
+}
+
+// encryptedBackend seals contents with AES-256-GCM
+type encryptedBackend struct {
+	material keyMaterial
+}
+
+func newEncryptedBackend() (Backend, error) {
+	material, err := loadKeyMaterial(true)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedBackend{material: material}, nil
+}
+
+func (b *encryptedBackend) Name() string { return "encrypted" }
+
+func (b *encryptedBackend) Seal(plaintext []byte) ([]byte, error) {
+	env := envelope{
+		Format: envelopeFormat,
+		KDF:    b.material.kdf,
+		Salt:   make([]byte, saltSize),
+	}
+	if env.KDF == kdfPBKDF2 {
+		env.Iterations = pbkdf2Iterations
+	}
+	if _, err := rand.Read(env.Salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := b.material.derive(env.Iterations, env.Salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	env.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	env.Ciphertext = aead.Seal(nil, env.Nonce, plaintext, []byte(envelopeFormat))
+
+	return json.MarshalIndent(env, "", "  ")
+}
+
+// open decrypts an encrypted secret file
+func open(data []byte) ([]byte, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted file: %w", err)
+	}
+
+	materials, err := openMaterials(env.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, material := range materials {
+		key, err := material.derive(env.Iterations, env.Salt)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := newGCM(key)
+		if err != nil {
+			return nil, err
+		}
+		if plaintext, err := aead.Open(nil, env.Nonce, env.Ciphertext, []byte(envelopeFormat)); err == nil {
+			return plaintext, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to decrypt secret file: wrong %s or corrupted file", kdfSourceName(env.KDF))
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
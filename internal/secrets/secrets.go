@@ -0,0 +1,180 @@
+// Package secrets reads and writes files that hold credentials, such as
+// OAuth tokens and IMAP passwords, through a configurable storage backend.
+//
+// The default "encrypted" backend seals file contents with AES-256-GCM. The
+// key is derived from LARK_SECRET_PASSPHRASE (PBKDF2-SHA256) when set, and
+// otherwise from a random key file (HKDF-SHA256) that is created on first use.
+// The "plaintext" backend keeps the previous behaviour of writing raw JSON.
+//
+// Reads accept both formats regardless of the configured backend, so
+// switching backends never strands existing files; Migrate rewrites a file
+// in the configured format.
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/yjwong/lark-cli/internal/config"
+)
+
+// Backend encodes secret file contents for storage
+type Backend interface {
+	// Name identifies the backend in config and command output
+	Name() string
+	// Seal converts plaintext into the stored representation
+	Seal(plaintext []byte) ([]byte, error)
+}
+
+// backends maps backend names to constructors
+var backends = map[string]func() (Backend, error){
+	"encrypted": newEncryptedBackend,
+	"plaintext": func() (Backend, error) { return plaintextBackend{}, nil },
+}
+
+// BackendNames returns the names of the available backends
+func BackendNames() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Current returns the backend selected by the secrets.backend setting
+func Current() (Backend, error) {
+	name := config.GetSecretsBackend()
+	newBackend, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown secrets backend %q (available: %v)", name, BackendNames())
+	}
+	return newBackend()
+}
+
+// plaintextBackend stores contents unchanged
+type plaintextBackend struct{}
+
+func (plaintextBackend) Name() string { return "plaintext" }
+
+func (plaintextBackend) Seal(plaintext []byte) ([]byte, error) { return plaintext, nil }
+
+// ReadFile returns the decoded contents of a secret file. Errors from the
+// filesystem are returned unwrapped so os.IsNotExist works on them.
+func ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	return open(data)
+}
+
+// WriteFile encodes data with the configured backend and atomically replaces
+// the file, which is created with mode 0600
+func WriteFile(path string, data []byte) error {
+	backend, err := Current()
+	if err != nil {
+		return err
+	}
+
+	sealed, err := backend.Seal(data)
+	if err != nil {
+		return err
+	}
+
+	return writeAtomic(path, sealed)
+}
+
+// MigrateResult describes what Migrate did to one file
+type MigrateResult struct {
+	Path   string `json:"path"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+	Status string `json:"status"` // migrated, unchanged, missing
+}
+
+// Migrate rewrites a secret file in the configured backend's format. Files
+// already in that format are left untouched unless rekey is set or their key
+// source differs from the current one, in which case they are re-sealed with
+// a fresh salt and the current key.
+func Migrate(path string, rekey, dryRun bool) (MigrateResult, error) {
+	result := MigrateResult{Path: path}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			result.Status = "missing"
+			return result, nil
+		}
+		return result, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	backend, err := Current()
+	if err != nil {
+		return result, err
+	}
+
+	result.From = "plaintext"
+	if IsEncrypted(raw) {
+		result.From = "encrypted"
+	}
+	result.To = backend.Name()
+
+	// An encrypted file also needs rewriting when the key source changed,
+	// e.g. from the key file to LARK_SECRET_PASSPHRASE
+	if eb, ok := backend.(*encryptedBackend); ok && result.From == "encrypted" && envelopeKDF(raw) != eb.material.kdf {
+		rekey = true
+	}
+
+	if result.From == result.To && !(rekey && result.From == "encrypted") {
+		result.Status = "unchanged"
+		return result, nil
+	}
+
+	result.Status = "migrated"
+	if dryRun {
+		return result, nil
+	}
+
+	data, err := ReadFile(path)
+	if err != nil {
+		return result, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	if err := WriteFile(path, data); err != nil {
+		return result, fmt.Errorf("failed to rewrite %s: %w", path, err)
+	}
+
+	return result, nil
+}
+
+// writeAtomic writes data to a temporary file in the same directory and
+// renames it over path, so an interrupted write never truncates a secret
+func writeAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}